@@ -28,3 +28,11 @@ For convenience, you can, first,  generate a cleura configuration file with `gen
 and then issue `cleura token get -u <username> -p <password> --update-config` command. Token will then be written to the configuration file in **open text**. Following `cleura` CLI commands will first try to use configuration file for receiving `username` and `token` values. Use the same command if token is revoked or outdated.
 
 Commands that require `username` and `token` values would also attempt to read `CLEURA_API_USERNAME` and `CLEURA_API_TOKEN` environmental variables.
+
+## Upgrading the client library
+
+`pkg/api/cleura` has breaking changes for code using the client directly:
+
+- Every client method and `NewClient` take a `context.Context` as the first argument.
+- `Client.Token` is deprecated. Tokens are managed by the client, use `Client.AccessToken` to read the current token and `Client.SetToken` to replace it. Assigning `Client.Token` still works, but is not safe while requests are in flight.
+- `cleura.New` with options such as `cleura.WithToken` or `cleura.WithCredentials` is the preferred way to create a client. `NewClient` and `NewClientNoPassword` are thin wrappers around it.
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"log"
 	"os"
+	"os/signal"
 	"slices"
 
+	"github.com/aztekas/cleura-client-go/cmd/cleura/cloudprofilecmd"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/cmd/cleura/devcmd"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/domaincmd"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/projectcmd"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/shootcmd"
@@ -27,6 +31,8 @@ func commands() []*cli.Command {
 		projectcmd.Command(),
 		tokencmd.Command(),
 		shootcmd.Command(),
+		cloudprofilecmd.Command(),
+		devcmd.Command(),
 	)
 }
 
@@ -48,8 +54,22 @@ func main() {
 				return nil
 			},
 		},
+		&cli.Float64Flag{
+			Name:    "max-rps",
+			Usage:   "Max number of Cleura API requests per second. Not limited if 0",
+			EnvVars: []string{"CLEURA_API_MAX_RPS"},
+			Action: func(ctx *cli.Context, f float64) error {
+				if f < 0 {
+					return errors.New("--max-rps must not be negative")
+				}
+				return nil
+			},
+		},
 	}
-	err := app.Run(os.Args)
+	// Cancel in-flight API calls on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := app.RunContext(ctx, os.Args)
+	stop()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,122 @@
+package cloudprofilecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:        "cloudprofile",
+		Description: "Command used to query cloud profile for kubernetes versions, machine types, images and regions available for shoot clusters",
+		Usage:       "Command used to query cloud profile for values available for shoot clusters",
+		Subcommands: []*cli.Command{
+			kubernetesCommand(),
+			machineTypesCommand(),
+			imagesCommand(),
+			regionsCommand(),
+		},
+	}
+}
+
+// Flags shared by all cloudprofile subcommands.
+func commonFlags() []cli.Flag {
+	return append(
+		common.CleuraAuthFlags(),
+		&cli.StringFlag{
+			Name:        "gardener-domain",
+			Category:    "Location settings",
+			Usage:       "Specify gardener domain, defaults to 'public'",
+			EnvVars:     []string{"CLEURA_API_GARDENER_DOMAIN"},
+			DefaultText: "public",
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "Output format, one of: table, json, yaml",
+			Value:   "table",
+			Action: func(ctx *cli.Context, s string) error {
+				if !slices.Contains([]string{"table", "json", "yaml"}, s) {
+					return fmt.Errorf("error: --output must be one of `table, json, yaml`")
+				}
+				return nil
+			},
+		},
+	)
+}
+
+// Fetch cloud profile of the gardener domain.
+func getCloudProfile(ctx *cli.Context) (*cleura.CloudProfile, error) {
+	err := common.ValidateNotEmptyString(ctx,
+		"token",
+		"username",
+		"api-host",
+	)
+	if err != nil {
+		return nil, err
+	}
+	client, err := common.CleuraClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gardenDomain := ctx.String("gardener-domain")
+	if gardenDomain == "" {
+		gardenDomain = cleura.DefaultGardenDomain
+	}
+	profile, err := client.GetCloudProfile(ctx.Context, gardenDomain)
+	if err != nil {
+		return nil, common.HandleAPIError(err)
+	}
+	return profile, nil
+}
+
+// Print data as json or yaml if requested by --output, otherwise render the table.
+func printOutput(ctx *cli.Context, data any, t table.Writer) error {
+	switch ctx.String("output") {
+	case "json":
+		raw, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(raw))
+	case "yaml":
+		// Round trip through json to keep field names of json tags
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err := yaml.Unmarshal(raw, &generic); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(out))
+	default:
+		fmt.Println(t.Render())
+	}
+	return nil
+}
+
+// Check if value is allowed by a filter. Empty filter allows everything.
+func matchFilter(filter []string, value string) bool {
+	return len(filter) == 0 || slices.Contains(filter, value)
+}
+
+// Mark expired dates in tables.
+func expirationDate(v cleura.CPVersion, now time.Time) string {
+	if v.Expired(now) {
+		return v.ExpirationDate + " (expired)"
+	}
+	return v.ExpirationDate
+}
@@ -0,0 +1,77 @@
+package cloudprofilecmd
+
+import (
+	"slices"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/urfave/cli/v2"
+)
+
+func imagesCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "images",
+		Description: "List machine images and their versions available for worker groups, newest first",
+		Usage:       "List machine images available for worker groups",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			commonFlags(),
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "Only list versions of given image",
+			},
+			&cli.StringSliceFlag{
+				Name:  "classification",
+				Usage: "Only list versions with given classification: preview, supported or deprecated. Can be repeated",
+			},
+			&cli.BoolFlag{
+				Name:  "usable-only",
+				Usage: "Only list versions usable for new worker groups (not preview and not expired)",
+			},
+			&cli.BoolFlag{
+				Name:  "latest",
+				Usage: "Only list the latest usable version of each image",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			profile, err := getCloudProfile(ctx)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			latest := profile.Spec.LatestMachineImageVersions()
+			var filtered []cleura.CPMachineImage
+			t := table.NewWriter()
+			t.Style().Format.Header = text.FormatTitle
+			t.AppendHeader(table.Row{"Image", "Version", "Classification", "Expiration date", "Latest"})
+			for _, image := range profile.Spec.MachineImages {
+				if ctx.String("name") != "" && image.Name != ctx.String("name") {
+					continue
+				}
+				versions := slices.Clone(image.Versions)
+				slices.SortFunc(versions, func(a, b cleura.CPVersion) int {
+					return cleura.CompareVersions(b.Version, a.Version)
+				})
+				var kept []cleura.CPVersion
+				for _, v := range versions {
+					isLatest := latest[image.Name].Version == v.Version
+					if !matchFilter(ctx.StringSlice("classification"), v.Classification) ||
+						(ctx.Bool("usable-only") && !v.Usable(now)) ||
+						(ctx.Bool("latest") && !isLatest) {
+						continue
+					}
+					kept = append(kept, v)
+					t.AppendRow(table.Row{image.Name, v.Version, v.Classification, expirationDate(v, now), isLatest})
+				}
+				if len(kept) > 0 {
+					filtered = append(filtered, cleura.CPMachineImage{Name: image.Name, Versions: kept})
+				}
+			}
+			t.SetColumnConfigs([]table.ColumnConfig{{Number: 1, AutoMerge: true}})
+			return printOutput(ctx, filtered, t)
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package cloudprofilecmd
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/urfave/cli/v2"
+)
+
+func kubernetesCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "kubernetes",
+		Description: "List kubernetes versions available for shoot clusters, newest first",
+		Usage:       "List kubernetes versions available for shoot clusters",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			commonFlags(),
+			&cli.StringSliceFlag{
+				Name:  "classification",
+				Usage: "Only list versions with given classification: preview, supported or deprecated. Can be repeated",
+			},
+			&cli.BoolFlag{
+				Name:  "usable-only",
+				Usage: "Only list versions usable for new clusters (not preview and not expired)",
+			},
+			&cli.StringFlag{
+				Name:  "upgradable-from",
+				Usage: "Only list versions a cluster running given version can be upgraded to",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			profile, err := getCloudProfile(ctx)
+			if err != nil {
+				return err
+			}
+			versions := profile.Spec.Kubernetes.Versions
+			if from := ctx.String("upgradable-from"); from != "" {
+				versions, err = profile.Spec.UpgradableKubernetesVersions(from)
+				if err != nil {
+					return fmt.Errorf("error: %w", err)
+				}
+			}
+			now := time.Now()
+			var filtered []cleura.CPVersion
+			for _, v := range versions {
+				if !matchFilter(ctx.StringSlice("classification"), v.Classification) || (ctx.Bool("usable-only") && !v.Usable(now)) {
+					continue
+				}
+				filtered = append(filtered, v)
+			}
+			slices.SortFunc(filtered, func(a, b cleura.CPVersion) int {
+				return cleura.CompareVersions(b.Version, a.Version)
+			})
+			latest, _ := profile.Spec.LatestSupportedKubernetesVersion()
+			t := table.NewWriter()
+			t.Style().Format.Header = text.FormatTitle
+			t.AppendHeader(table.Row{"Version", "Classification", "Expiration date", "Latest supported"})
+			for _, v := range filtered {
+				t.AppendRow(table.Row{v.Version, v.Classification, expirationDate(v, now), v.Version == latest.Version})
+			}
+			return printOutput(ctx, filtered, t)
+		},
+	}
+}
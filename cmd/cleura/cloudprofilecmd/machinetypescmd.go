@@ -0,0 +1,71 @@
+package cloudprofilecmd
+
+import (
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/urfave/cli/v2"
+)
+
+func machineTypesCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "machine-types",
+		Description: "List machine types available for worker groups",
+		Usage:       "List machine types available for worker groups",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			commonFlags(),
+			&cli.StringFlag{
+				Name:  "architecture",
+				Usage: "Only list machine types of given architecture (ex: amd64)",
+			},
+			&cli.BoolFlag{
+				Name:  "usable-only",
+				Usage: "Only list usable machine types",
+			},
+			&cli.Float64Flag{
+				Name:  "min-cpu",
+				Usage: "Only list machine types with at least given number of CPU cores",
+			},
+			&cli.Float64Flag{
+				Name:  "min-memory",
+				Usage: "Only list machine types with at least given memory in GiB",
+			},
+			&cli.IntFlag{
+				Name:  "min-gpu",
+				Usage: "Only list machine types with at least given number of GPUs",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			profile, err := getCloudProfile(ctx)
+			if err != nil {
+				return err
+			}
+			filter := cleura.MachineTypeFilter{
+				Architecture: ctx.String("architecture"),
+				UsableOnly:   ctx.Bool("usable-only"),
+				MinCPU:       ctx.Float64("min-cpu"),
+				MinMemory:    int64(ctx.Float64("min-memory") * (1 << 30)),
+				MinGPU:       ctx.Int("min-gpu"),
+			}
+			types, err := profile.Spec.FindMachineTypes(filter)
+			if err != nil {
+				return err
+			}
+			var filtered []cleura.CPMachineType
+			t := table.NewWriter()
+			t.Style().Format.Header = text.FormatTitle
+			t.AppendHeader(table.Row{"Name", "CPU", "Memory\n(GiB)", "GPU", "Architecture", "Usable"})
+			for _, m := range types {
+				t.AppendRow(table.Row{m.Name, m.CPU, m.MemoryGiB(), m.GPU, m.Architecture, m.Usable})
+				for _, raw := range profile.Spec.MachineTypes {
+					if raw.Name == m.Name {
+						filtered = append(filtered, raw)
+					}
+				}
+			}
+			return printOutput(ctx, filtered, t)
+		},
+	}
+}
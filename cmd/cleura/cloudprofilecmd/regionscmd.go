@@ -0,0 +1,59 @@
+package cloudprofilecmd
+
+import (
+	"strings"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/urfave/cli/v2"
+)
+
+func regionsCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "regions",
+		Description: "List regions and zones available for shoot clusters along with volume types unavailable in each zone",
+		Usage:       "List regions and zones available for shoot clusters",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			commonFlags(),
+			&cli.StringSliceFlag{
+				Name:  "region",
+				Usage: "Only list given region. Can be repeated",
+			},
+			&cli.StringFlag{
+				Name:  "volume-type",
+				Usage: "Only list zones where given volume type is available",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			profile, err := getCloudProfile(ctx)
+			if err != nil {
+				return err
+			}
+			var filtered []cleura.CPRegion
+			t := table.NewWriter()
+			t.Style().Format.Header = text.FormatTitle
+			t.AppendHeader(table.Row{"Region", "Zone", "Unavailable\nvolume types"})
+			for _, region := range profile.Spec.Regions {
+				if !matchFilter(ctx.StringSlice("region"), region.Name) {
+					continue
+				}
+				var zones []cleura.CPZone
+				for _, zone := range region.Zones {
+					if volumeType := ctx.String("volume-type"); volumeType != "" && !zone.VolumeTypeAvailable(volumeType) {
+						continue
+					}
+					zones = append(zones, zone)
+					t.AppendRow(table.Row{region.Name, zone.Name, strings.Join(zone.UnavailableVolumeTypes, "\n")})
+				}
+				if len(zones) > 0 {
+					filtered = append(filtered, cleura.CPRegion{Name: region.Name, Zones: zones})
+				}
+			}
+			t.SetColumnConfigs([]table.ColumnConfig{{Number: 1, AutoMerge: true}})
+			return printOutput(ctx, filtered, t)
+		},
+	}
+}
@@ -1,10 +1,12 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
 	"github.com/urfave/cli/v2"
 )
 
@@ -76,6 +78,42 @@ func ValidateNotEmptyString(ctx *cli.Context, flags ...string) error {
 	return nil
 }
 
+// Create Cleura API client from auth flags. Client logs requests when --loglevel is debug
+// and is rate limited with --max-rps.
+func CleuraClient(ctx *cli.Context) (*cleura.Client, error) {
+	opts := []cleura.Option{
+		cleura.WithHostURL(ctx.String("api-host")),
+		cleura.WithToken(ctx.String("username"), ctx.String("token")),
+		cleura.WithLogger(CliLogger(ctx.String("loglevel"))),
+	}
+	if rps := ctx.Float64("max-rps"); rps > 0 {
+		opts = append(opts, cleura.WithRateLimit(rps, 0))
+	}
+	return cleura.New(opts...)
+}
+
+// Translate Cleura API errors into user friendly messages.
+func HandleAPIError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var re *cleura.RequestAPIError
+	if !errors.As(err, &re) {
+		return err
+	}
+	switch {
+	case errors.Is(err, cleura.ErrUnauthorized):
+		return fmt.Errorf("error: invalid token")
+	case errors.Is(err, cleura.ErrRateLimited):
+		return fmt.Errorf("error: request rate limited by Cleura API, try again later")
+	case re.APIBody != nil && re.APIBody.Message != "":
+		return fmt.Errorf("error: %s", re.APIBody.Message)
+	}
+	return err
+}
+
+// Create logger writing to standard error, so that logs do not mix with command output
+// such as kubeconfigs or exported manifests.
 func CliLogger(level string) *slog.Logger {
 	logLevel := &slog.LevelVar{}
 	switch level {
@@ -92,6 +130,6 @@ func CliLogger(level string) *slog.Logger {
 		Level: logLevel,
 		//AddSource: true,
 	}
-	handler := slog.NewTextHandler(os.Stdout, opts)
+	handler := slog.NewTextHandler(os.Stderr, opts)
 	return slog.New(handler)
 }
@@ -43,9 +43,31 @@ func TrySetConfigFromFile(c *cli.Context) error {
 			}
 		}
 	}
+	// Global flags are set on the app context, which is the topmost context defining them
+	for _, flag := range c.App.Flags {
+		value, inConfig := profileMap[flag.Names()[0]].(string)
+		if !inConfig || value == "" || c.IsSet(flag.Names()[0]) {
+			continue
+		}
+		err = setOnLineage(c, flag.Names()[0], value)
+		if err != nil {
+			return fmt.Errorf("error: setting `%s` from configuration file: %w", flag.Names()[0], err)
+		}
+	}
 	return nil
 }
 
+// Set flag value on the first context in lineage that defines the flag.
+func setOnLineage(c *cli.Context, name string, value string) error {
+	var err error
+	for _, lc := range c.Lineage() {
+		if err = lc.Set(name, value); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 func Command() *cli.Command {
 	return &cli.Command{
 		Name:        "config",
@@ -0,0 +1,14 @@
+package devcmd
+
+import "github.com/urfave/cli/v2"
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:        "dev",
+		Description: "Command used for local development and demos",
+		Usage:       "Command used for local development and demos",
+		Subcommands: []*cli.Command{
+			mockServerCommand(),
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package devcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+	"github.com/urfave/cli/v2"
+)
+
+func mockServerCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "mock-server",
+		Description: "Run in-memory fake Cleura API. Point --api-host at it to try the CLI without a Cleura account",
+		Usage:       "Run in-memory fake Cleura API",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "port",
+				Usage: "Port to listen on",
+				Value: 8080,
+			},
+			&cli.StringFlag{
+				Name:  "address",
+				Usage: "Address to listen on",
+				Value: "127.0.0.1",
+			},
+			&cli.DurationFlag{
+				Name:  "operation-duration",
+				Usage: "How long simulated shoot operations take",
+				Value: cleuratest.DefaultOperationDuration,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			logger := common.CliLogger(ctx.String("loglevel"))
+			api := cleuratest.NewAPI()
+			api.SetOperationDuration(ctx.Duration("operation-duration"))
+			addr := net.JoinHostPort(ctx.String("address"), strconv.Itoa(ctx.Int("port")))
+			server := &http.Server{
+				Addr:              addr,
+				Handler:           api,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			fmt.Printf("Fake Cleura API listening on http://%s\nUse following settings to access it:\n", addr)
+			fmt.Printf("\nexport CLEURA_API_HOST=http://%s\nexport CLEURA_API_USERNAME=%s\nexport CLEURA_API_TOKEN=%s\nexport CLEURA_API_DEFAULT_REGION=%s\nexport CLEURA_API_DEFAULT_PROJECT_ID=%s\nexport CLEURA_API_DEFAULT_DOMAIN_ID=%s\n\n",
+				addr,
+				cleuratest.DefaultUsername,
+				cleuratest.DefaultToken,
+				cleuratest.DefaultRegion,
+				cleuratest.DefaultProjectID,
+				cleuratest.DefaultDomainID,
+			)
+			fmt.Printf("Password for `%s` is `%s`. Stop with Ctrl-C\n", cleuratest.DefaultUsername, cleuratest.DefaultPassword)
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- server.ListenAndServe()
+			}()
+			select {
+			case err := <-errCh:
+				return err
+			case <-ctx.Context.Done():
+			}
+			logger.Info("shutting down fake Cleura API")
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err := server.Shutdown(shutdownCtx)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
-	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/urfave/cli/v2"
@@ -28,22 +27,13 @@ func listCommand() *cli.Command {
 			if err != nil {
 				return err
 			}
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
-			domains, err := client.ListDomains()
+			domains, err := client.ListDomains(ctx.Context)
 			if err != nil {
-				re, ok := err.(*cleura.RequestAPIError)
-				if ok {
-					if re.StatusCode == 403 {
-						return fmt.Errorf("error: invalid token")
-					}
-				}
-				return err
+				return common.HandleAPIError(err)
 			}
 			t := table.NewWriter()
 			t.SetAutoIndex(true)
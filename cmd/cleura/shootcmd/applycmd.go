@@ -0,0 +1,153 @@
+package shootcmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/manifest"
+	"github.com/urfave/cli/v2"
+)
+
+func applyCommand() *cli.Command {
+	commonFlags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	commonFlags = append(commonFlags, waitFlags()...)
+	commonFlags = append(commonFlags, skipValidationFlag(), noColorFlag())
+	return &cli.Command{
+		Name:        "apply",
+		Description: "Create or update shoot clusters to match manifests. Worker groups missing from a manifest are deleted. Region and project not set in manifest metadata are taken from --region and --project-id",
+		Usage:       "Create or update shoot clusters to match manifests",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			commonFlags,
+			&cli.StringSliceFlag{
+				Name:     "filename",
+				Aliases:  []string{"f"},
+				Usage:    "Manifest file or directory of manifests, - reads standard input. Can be repeated",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "plan",
+				Usage: fmt.Sprintf("Only show planned changes without applying them. Exits with code %d if any shoot cluster differs from its manifest", driftExitCode),
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			err := common.ValidateNotEmptyString(ctx,
+				"token",
+				"username",
+				"api-host",
+			)
+			if err != nil {
+				return err
+			}
+			shoots, err := manifest.Load(ctx.StringSlice("filename")...)
+			if err != nil {
+				return fmt.Errorf("error: %w", err)
+			}
+			client, err := common.CleuraClient(ctx)
+			if err != nil {
+				return err
+			}
+			profiles := newCloudProfileCache(client)
+			printer := newPlanPrinter(ctx)
+			drift := 0
+			for _, shoot := range shoots {
+				plan, err := planManifest(ctx, client, profiles, shoot)
+				if err != nil {
+					return err
+				}
+				printer.print(plan)
+				if plan.Drift() {
+					drift++
+				}
+				if plan.Empty() || ctx.Bool("plan") {
+					continue
+				}
+				if err := applyPlan(ctx, client, plan); err != nil {
+					return err
+				}
+			}
+			if ctx.Bool("plan") {
+				return driftError(drift)
+			}
+			return nil
+		},
+	}
+}
+
+// Resolve location and versions of the manifest, plan changes and validate them.
+func planManifest(ctx *cli.Context, client *cleura.Client, profiles *cloudProfileCache, shoot *manifest.Shoot) (*manifest.Plan, error) {
+	loc, err := shoot.Location(cleura.ShootLocation{
+		GardenDomain: ctx.String("gardener-domain"),
+		Region:       ctx.String("region"),
+		ProjectID:    ctx.String("project-id"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error: %w", err)
+	}
+	var profile *cleura.CloudProfile
+	if !ctx.Bool("skip-validation") || manifestHasVersionQueries(shoot) {
+		if profile, err = profiles.get(ctx, loc.GardenDomain); err != nil {
+			return nil, err
+		}
+		if err := shoot.ResolveVersions(profile, time.Now()); err != nil {
+			return nil, fmt.Errorf("error: %w", err)
+		}
+	}
+	plan, err := manifest.GetPlan(ctx.Context, client, shoot, loc)
+	if errors.Is(err, cleura.ErrInvalidRequest) {
+		return nil, requestValidationError(ctx, shoot.String(), err)
+	} else if err != nil {
+		return nil, common.HandleAPIError(err)
+	}
+	// Only changed fields are validated, so live values expired since do not fail the plan
+	if !ctx.Bool("skip-validation") {
+		if err := plan.Validate(profile); err != nil {
+			return nil, requestValidationError(ctx, shoot.String(), err)
+		}
+	}
+	return plan, nil
+}
+
+func manifestHasVersionQueries(shoot *manifest.Shoot) bool {
+	if cleura.IsVersionQuery(shoot.Spec.Kubernetes.Version) {
+		return true
+	}
+	for _, w := range shoot.Spec.Workers {
+		if cleura.IsVersionQuery(w.Machine.Image.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute actions of the plan showing operation progress on stderr.
+func applyPlan(ctx *cli.Context, client *cleura.Client, plan *manifest.Plan) error {
+	progress := newProgressPrinter(plan.Shoot.Metadata.Name)
+	err := manifest.Apply(ctx.Context, client, plan, manifest.ApplyOptions{
+		Wait: cleura.WaitOptions{
+			Interval:       ctx.Duration("poll-interval"),
+			Timeout:        ctx.Duration("timeout"),
+			RequireHealthy: true,
+			Progress:       progress.update,
+		},
+		WaitForLast: ctx.Bool("wait"),
+		OnAction: func(action manifest.Action) {
+			progress.done()
+			fmt.Printf("Cluster: `%s`: %s\n", plan.Shoot.Metadata.Name, action)
+		},
+	})
+	progress.done()
+	if err != nil {
+		return common.HandleAPIError(err)
+	}
+	if ctx.Bool("wait") {
+		fmt.Printf("Cluster: `%s` is applied\n", plan.Shoot.Metadata.Name)
+	} else {
+		fmt.Printf("Cluster: `%s` is being updated.\nPlease check status with `cleura shoot list` command\n", plan.Shoot.Metadata.Name)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package shootcmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag disabling validation of requests against the cloud profile.
+func skipValidationFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "skip-validation",
+		Usage: "Submit request without validating it against the cloud profile first",
+	}
+}
+
+// Fetch cloud profile of `gardenDomain` if it is needed to validate the request or to resolve any of
+// `versionFlags`. Returns nil profile if it is not needed.
+func requestCloudProfile(ctx *cli.Context, client *cleura.Client, gardenDomain string, versionFlags ...string) (*cleura.CloudProfile, error) {
+	needed := !ctx.Bool("skip-validation")
+	for _, flag := range versionFlags {
+		needed = needed || cleura.IsVersionQuery(ctx.String(flag))
+	}
+	if !needed {
+		return nil, nil
+	}
+	profile, err := client.GetCloudProfile(ctx.Context, gardenDomain)
+	if err != nil {
+		return nil, fmt.Errorf("error: fetching cloud profile failed: %w", common.HandleAPIError(err))
+	}
+	return profile, nil
+}
+
+// Replace `latest`, `latest-supported` and `<major>.<minor>` values of --k8s-version and
+// --wg-image-version with versions from the cloud profile.
+func resolveVersionFlags(ctx *cli.Context, profile *cleura.CloudProfile, versionFlags ...string) error {
+	now := time.Now()
+	for _, flag := range versionFlags {
+		query := ctx.String(flag)
+		if !cleura.IsVersionQuery(query) {
+			continue
+		}
+		var version string
+		var err error
+		switch flag {
+		case "k8s-version":
+			version, err = profile.Spec.ResolveKubernetesVersion(query, now)
+		case "wg-image-version":
+			version, err = profile.Spec.ResolveMachineImageVersion(ctx.String("wg-image-name"), query, now)
+		}
+		if err != nil {
+			return fmt.Errorf("error: resolving --%s: %w", flag, err)
+		}
+		if err := ctx.Set(flag, version); err != nil {
+			return err
+		}
+		fmt.Printf("Resolved --%s `%s` to %s\n", flag, query, version)
+	}
+	return nil
+}
+
+// Format problems found by validation of request for `subject`, one per line.
+func requestValidationError(ctx *cli.Context, subject string, err error) error {
+	var ve *cleura.ValidationError
+	if !errors.As(err, &ve) {
+		return err
+	}
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "error: invalid request for %s:\n", subject)
+	for _, problem := range ve.Problems {
+		fmt.Fprintf(&msg, "  - %s\n", problem)
+	}
+	if !ctx.Bool("skip-validation") {
+		msg.WriteString("Use --skip-validation to skip checks against the cloud profile")
+	}
+	return errors.New(strings.TrimSuffix(msg.String(), "\n"))
+}
+
+// Cloud profiles fetched by gardener domain, so that applying many manifests fetches each profile once.
+type cloudProfileCache struct {
+	client   *cleura.Client
+	profiles map[string]*cleura.CloudProfile
+}
+
+func newCloudProfileCache(client *cleura.Client) *cloudProfileCache {
+	return &cloudProfileCache{client: client, profiles: make(map[string]*cleura.CloudProfile)}
+}
+
+func (c *cloudProfileCache) get(ctx *cli.Context, gardenDomain string) (*cleura.CloudProfile, error) {
+	if profile, ok := c.profiles[gardenDomain]; ok {
+		return profile, nil
+	}
+	profile, err := c.client.GetCloudProfile(ctx.Context, gardenDomain)
+	if err != nil {
+		return nil, fmt.Errorf("error: fetching cloud profile failed: %w", common.HandleAPIError(err))
+	}
+	c.profiles[gardenDomain] = profile
+	return profile, nil
+}
@@ -1,17 +1,21 @@
 package shootcmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
 	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/manifest"
 	"github.com/urfave/cli/v2"
 )
 
 func createCommand() *cli.Command {
 	commonFlags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	commonFlags = append(commonFlags, waitFlags()...)
+	commonFlags = append(commonFlags, skipValidationFlag())
 	return &cli.Command{
 		Name:        "create",
 		Description: "Create shoot cluster or add a workergroup",
@@ -62,8 +66,8 @@ func createCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:     "k8s-version",
 				Category: "Basic cluster settings",
-				Usage:    "Supported Kubernetes version",
-				Value:    "1.28.8",
+				Usage:    "Kubernetes version. Either a full version, latest (may be a preview version), latest-supported or <major>.<minor> for the latest usable patch version",
+				Value:    cleura.VersionLatestSupported,
 			},
 			&cli.BoolFlag{
 				Name:     "enable-ha-control-plane",
@@ -98,8 +102,8 @@ func createCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:     "wg-image-version",
 				Category: "Workergroup settings",
-				Usage:    "Workergroup image version",
-				Value:    "1312.3.0",
+				Usage:    "Workergroup image version. Either a full version, latest (may be a preview version), latest-supported or <major>.<minor> for the latest usable patch version",
+				Value:    cleura.VersionLatestSupported,
 			},
 			&cli.StringFlag{
 				Name:     "wg-volume-size",
@@ -107,6 +111,11 @@ func createCommand() *cli.Command {
 				Usage:    "Workergroup machine volume size",
 				Value:    "50Gi",
 			},
+			&cli.StringFlag{
+				Name:     "wg-volume-type",
+				Category: "Workergroup settings",
+				Usage:    "Workergroup machine volume type, must be available in zones of the workergroup",
+			},
 			&cli.StringSliceFlag{
 				Name:     "wg-annotation",
 				Category: "Workergroup settings",
@@ -146,6 +155,17 @@ func createCommand() *cli.Command {
 					return nil
 				},
 			},
+			&cli.GenericFlag{
+				Name:     "workergroup-spec",
+				Category: "Workergroup settings",
+				Usage:    "Add a workergroup to the new cluster, can be set multiple times. Supplied as comma separated key=value fields: name, type, image, image-version, min, max, max-surge, volume-size, volume-type, zones (separated by ;), label, annotation and taint (ex: \"name=sys,type=b.4c8gb,min=2,max=4,zones=a;b,taint=k=v:NoSchedule\"). Fields not set default to --wg-* flags, labels, annotations and taints of --wg-label, --wg-annotation and --wg-taint are added to those of the spec",
+				Value:    &workerGroupSpecs{},
+			},
+			&cli.StringFlag{
+				Name:     "workers-file",
+				Category: "Workergroup settings",
+				Usage:    "Add workergroups listed in a YAML file to the new cluster, in the format of spec.workers of shoot manifests. - reads standard input",
+			},
 			&cli.StringSliceFlag{
 				Name:     "wg-zone",
 				Category: "Workergroup settings",
@@ -250,45 +270,81 @@ func createCommand() *cli.Command {
 			if !ctx.Bool("cluster") && !ctx.Bool("workergroup") {
 				return fmt.Errorf("error: one of `--cluster` or `--workergroup` must be set")
 			}
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
 			if ctx.Bool("cluster") {
-				clusterReq := generateShootClusterRequest(ctx)
-				_, err := client.CreateShootCluster(ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"), clusterReq)
+				workers, err := workerGroupsFromFlags(ctx)
+				if err != nil {
+					return err
+				}
+				versionFlags := []string{"k8s-version", "wg-image-version"}
+				if len(workers) > 0 {
+					versionFlags = versionFlags[:1]
+				}
+				profile, err := requestCloudProfile(ctx, client, ctx.String("gardener-domain"), versionFlags...)
+				if err != nil {
+					return err
+				}
+				if err := resolveVersionFlags(ctx, profile, versionFlags...); err != nil {
+					return err
+				}
+				if profile, err = resolveWorkerVersions(ctx, client, profile, workers); err != nil {
+					return err
+				}
+				builder, err := shootClusterBuilder(ctx, workers)
 				if err != nil {
-					re, ok := err.(*cleura.RequestAPIError)
-					if ok {
-						if re.StatusCode == 403 {
-							return fmt.Errorf("error: invalid token")
-						}
-					}
 					return err
 				}
+				if !ctx.Bool("skip-validation") {
+					builder.WithCloudProfile(profile, ctx.String("region"))
+				}
+				clusterReq, err := builder.Build()
+				if err != nil {
+					return requestValidationError(ctx, fmt.Sprintf("shoot `%s`", ctx.String("cluster-name")), err)
+				}
+				_, err = client.CreateShootCluster(ctx.Context, ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"), clusterReq)
+				if err != nil {
+					if errors.Is(err, cleura.ErrConflict) {
+						return fmt.Errorf("error: shoot `%s` already exists in project %s/region %s", ctx.String("cluster-name"), ctx.String("project-id"), ctx.String("region"))
+					}
+					return common.HandleAPIError(err)
+				}
+				if ctx.Bool("wait") {
+					fmt.Printf("Cluster: `%s` is being created\n", ctx.String("cluster-name"))
+					return waitForOperation(ctx, client, cleura.WaitOptions{RequireHealthy: true})
+				}
 				fmt.Printf("Cluster: `%s` is being created.\nPlease check status with `cleura shoot list` command\n", ctx.String("cluster-name"))
 
 			}
 			if ctx.Bool("workergroup") {
-				wgReq := generateWorkerGroupRequest(ctx)
-				// body, err := json.MarshalIndent(wgReq, "", " ")
-				// if err != nil {
-				// 	return err
-				// }
-				// fmt.Printf("%s", string(body))
-				resp, err := client.AddWorkerGroup(ctx.String("gardener-domain"), ctx.String("cluster-name"), ctx.String("region"), ctx.String("project-id"), wgReq)
+				profile, err := requestCloudProfile(ctx, client, ctx.String("gardener-domain"), "wg-image-version")
 				if err != nil {
-					re, ok := err.(*cleura.RequestAPIError)
-					if ok {
-						if re.StatusCode == 403 {
-							return fmt.Errorf("error: invalid token")
-						}
-					}
 					return err
 				}
+				if err := resolveVersionFlags(ctx, profile, "wg-image-version"); err != nil {
+					return err
+				}
+				builder, err := workerGroupBuilder(ctx)
+				if err != nil {
+					return err
+				}
+				if !ctx.Bool("skip-validation") {
+					builder.WithCloudProfile(profile, ctx.String("region"))
+				}
+				wgReq, err := builder.Build()
+				if err != nil {
+					return requestValidationError(ctx, fmt.Sprintf("worker group of shoot `%s`", ctx.String("cluster-name")), err)
+				}
+				resp, err := client.AddWorkerGroup(ctx.Context, ctx.String("gardener-domain"), ctx.String("cluster-name"), ctx.String("region"), ctx.String("project-id"), wgReq)
+				if err != nil {
+					return shootAPIError(ctx, err)
+				}
+				if ctx.Bool("wait") {
+					fmt.Printf("New workgroup is being added to the cluster `%s`\n", resp.Metadata.Name)
+					return waitForOperation(ctx, client, cleura.WaitOptions{})
+				}
 				fmt.Printf("New workgroup is being added to the cluster `%s`.\nPlease check status with `cleura shoot list` command\n", resp.Metadata.Name)
 			}
 			return nil
@@ -296,161 +352,69 @@ func createCommand() *cli.Command {
 	}
 }
 
-func generateShootClusterRequest(ctx *cli.Context) cleura.ShootClusterRequest {
-	clusterReq := cleura.ShootClusterRequest{
-		Shoot: cleura.ShootClusterRequestConfig{
-			Name: ctx.String("cluster-name"),
-			KubernetesVersion: &cleura.K8sVersion{
-				Version: ctx.String("k8s-version"),
-			},
-			EnableHaControlPlane: ctx.Bool("enable-ha-control-plane"),
-			Maintenance: &cleura.MaintenanceDetails{
-				AutoUpdate: &cleura.AutoUpdateDetails{
-					KubernetesVersion:   ctx.Bool("allow-k8s-autoupdate"),
-					MachineImageVersion: ctx.Bool("allow-worker-image-autoupdate"),
-				},
-				TimeWindow: &cleura.TimeWindowDetails{
-					Begin: "000000+0000",
-					End:   "010000+0000",
-				},
-			},
-			Provider: &cleura.ProviderDetailsRequest{
-				InfrastructureConfig: cleura.InfrastructureConfigDetails{
-					FloatingPoolName: "ext-net",
-				},
-				Workers: []cleura.WorkerRequest{
-					{
-						Minimum: int16(ctx.Int("wg-min")),
-						Maximum: int16(ctx.Int("wg-max")),
-						Machine: cleura.MachineDetails{
-							Type: ctx.String("wg-type"),
-							Image: cleura.ImageDetails{
-								Name:    ctx.String("wg-image-name"),
-								Version: ctx.String("wg-image-version"),
-							},
-						},
-						Volume: cleura.VolumeDetails{
-							Size: ctx.String("wg-volume-size"),
-						},
-						Annotations: stringSliceToKeyValueSlice(ctx.StringSlice("wg-annotation")),
-						Labels:      stringSliceToKeyValueSlice(ctx.StringSlice("wg-labels")),
-						Taints:      taintStringSliceToKeyValueSlice(ctx.StringSlice("wg-taints")),
-						Zones:       ctx.StringSlice("wg-labels"),
-					},
-				},
-			},
-		},
-	}
-	// Give name to a worker group if provided, otherwise it will be generated automatically
-	if ctx.String("wg-name") != "" {
-		clusterReq.Shoot.Provider.Workers[0].Name = ctx.String("wg-name")
+// Build shoot cluster request from flags. Workergroup is built from `--wg-*` flags unless `workers` are given.
+func shootClusterBuilder(ctx *cli.Context, workers []manifest.Worker) (*cleura.ShootClusterBuilder, error) {
+	builder := cleura.NewShootClusterBuilder(ctx.String("cluster-name")).
+		WithKubernetes(ctx.String("k8s-version"))
+	for _, w := range workers {
+		builder.AddWorkerGroup(w.Builder())
 	}
-
-	if ctx.String("maintenance-start") != "" && ctx.String("maintenance-end") != "" {
-		clusterReq.Shoot.Maintenance.TimeWindow = &cleura.TimeWindowDetails{
-			Begin: ctx.String("maintenance-start"),
-			End:   ctx.String("maintenance-end"),
+	if len(workers) == 0 {
+		wg, err := workerGroupBuilder(ctx)
+		if err != nil {
+			return nil, err
 		}
+		builder.AddWorkerGroup(wg)
 	}
-
-	if ctx.String("wg-name") != "" {
-		clusterReq.Shoot.Provider.Workers[0].Name = ctx.String("wg-name")
+	builder.
+		WithAutoUpdate(ctx.Bool("allow-k8s-autoupdate"), ctx.Bool("allow-worker-image-autoupdate")).
+		WithMaintenanceWindow("000000+0000", "010000+0000")
+	if ctx.Bool("enable-ha-control-plane") {
+		builder.WithHAControlPlane()
+	}
+	if ctx.String("maintenance-start") != "" && ctx.String("maintenance-end") != "" {
+		builder.WithMaintenanceWindow(ctx.String("maintenance-start"), ctx.String("maintenance-end"))
 	}
-
 	if ctx.String("hibernation-start") != "" && ctx.String("hibernation-end") != "" {
-		clusterReq.Shoot.Hibernation = &cleura.HibernationSchedules{
-			HibernationSchedules: []cleura.HibernationSchedule{
-				{
-					Start: ctx.String("hibernation-start"),
-					End:   ctx.String("hibernation-end"),
-				},
-			},
-		}
+		builder.WithHibernationSchedule(ctx.String("hibernation-start"), ctx.String("hibernation-end"))
 	}
-
 	if ctx.String("network-id") != "" && ctx.String("router-id") != "" {
-		clusterReq.Shoot.Provider.InfrastructureConfig.Networks = &cleura.WorkerNetwork{
-			Id: ctx.String("network-id"),
-			Router: cleura.Router{
-				Id: ctx.String("router-id"),
-			},
-		}
+		builder.WithExistingNetwork(ctx.String("network-id"), ctx.String("router-id"))
 	}
-
 	if cidr := ctx.String("subnet-cidr"); cidr != "" {
-		if clusterReq.Shoot.Provider.InfrastructureConfig.Networks == nil {
-			clusterReq.Shoot.Provider.InfrastructureConfig.Networks = &cleura.WorkerNetwork{}
-		}
-		clusterReq.Shoot.Provider.InfrastructureConfig.Networks.WorkersCIDR = cidr
+		builder.WithWorkersCIDR(cidr)
 	}
-
-	return clusterReq
+	return builder, nil
 }
 
-func stringSliceToKeyValueSlice(stringslice []string) []cleura.KeyValuePair {
-	kv := make([]cleura.KeyValuePair, 0)
-	if len(stringslice) > 0 {
-		for _, annotation := range stringslice {
-			keyValue := strings.SplitN(annotation, "=", 2)
-			if len(keyValue) != 2 {
-				panic(fmt.Errorf("expected annotation have a equal sign as delimited, got %s", annotation))
-			}
-			kv = append(kv, cleura.KeyValuePair{
-				Key:   keyValue[0],
-				Value: keyValue[1],
-			})
+// Build worker group request from `--wg-*` flags. Name is generated by the API if `--wg-name` is not set.
+func workerGroupBuilder(ctx *cli.Context) (*cleura.WorkerGroupBuilder, error) {
+	builder := cleura.NewWorkerGroupBuilder(ctx.String("wg-name")).
+		WithMachine(ctx.String("wg-type"), ctx.String("wg-image-name"), ctx.String("wg-image-version")).
+		WithAutoscaling(int16(ctx.Int("wg-min")), int16(ctx.Int("wg-max"))).
+		WithVolumeSize(ctx.String("wg-volume-size")).
+		WithVolumeType(ctx.String("wg-volume-type")).
+		WithZones(ctx.StringSlice("wg-zone")...)
+	for _, s := range ctx.StringSlice("wg-annotation") {
+		kv, err := cleura.ParseKeyValue(s)
+		if err != nil {
+			return nil, fmt.Errorf("error: --wg-annotation: %w", err)
 		}
+		builder.WithAnnotation(kv.Key, kv.Value)
 	}
-	return kv
-}
-
-func taintStringSliceToKeyValueSlice(stringslice []string) []cleura.Taint {
-	kv := make([]cleura.Taint, 0)
-	if len(stringslice) > 0 {
-		for _, annotation := range stringslice {
-			keyValue := strings.SplitN(annotation, "=", 2)
-			if len(keyValue) != 2 {
-				panic(fmt.Errorf("expected annotation have a equal sign as delimiter for key=value, got %s", annotation))
-			}
-			valueEffect := strings.SplitN(keyValue[1], ":", 2)
-			if len(keyValue) != 2 {
-				panic(fmt.Errorf("expected annotation have a colon sign as delimiter for value:effect, got %s", annotation))
-			}
-			kv = append(kv, cleura.Taint{
-				Key:    keyValue[0],
-				Value:  valueEffect[0],
-				Effect: valueEffect[1],
-			})
+	for _, s := range ctx.StringSlice("wg-label") {
+		kv, err := cleura.ParseKeyValue(s)
+		if err != nil {
+			return nil, fmt.Errorf("error: --wg-label: %w", err)
 		}
+		builder.WithLabel(kv.Key, kv.Value)
 	}
-	return kv
-}
-
-func generateWorkerGroupRequest(ctx *cli.Context) cleura.WorkerGroupRequest {
-
-	wgReq := cleura.WorkerGroupRequest{
-		Worker: cleura.WorkerRequest{
-			Minimum: int16(ctx.Int("wg-min")),
-			Maximum: int16(ctx.Int("wg-max")),
-			Machine: cleura.MachineDetails{
-				Type: ctx.String("wg-type"),
-				Image: cleura.ImageDetails{
-					Name:    ctx.String("wg-image-name"),
-					Version: ctx.String("wg-image-version"),
-				},
-			},
-			Volume: cleura.VolumeDetails{
-				Size: ctx.String("wg-volume-size"),
-			},
-			Annotations: stringSliceToKeyValueSlice(ctx.StringSlice("wg-annotation")),
-			Labels:      stringSliceToKeyValueSlice(ctx.StringSlice("wg-label")),
-			Taints:      []cleura.Taint{},
-			Zones:       ctx.StringSlice("wg-zones"),
-		},
-	}
-	if ctx.String("wg-name") != "" {
-		wgReq.Worker.Name = ctx.String("wg-name")
+	for _, s := range ctx.StringSlice("wg-taint") {
+		taint, err := cleura.ParseTaint(s)
+		if err != nil {
+			return nil, fmt.Errorf("error: --wg-taint: %w", err)
+		}
+		builder.WithTaint(taint.Key, taint.Value, taint.Effect)
 	}
-	fmt.Println(wgReq)
-	return wgReq
+	return builder, nil
 }
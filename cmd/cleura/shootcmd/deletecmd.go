@@ -11,6 +11,7 @@ import (
 
 func deleteCommand() *cli.Command {
 	commonFlags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	commonFlags = append(commonFlags, waitFlags()...)
 	return &cli.Command{
 		Name:        "delete",
 		Description: "Delete a cluster or a workgroup in the specified cluster",
@@ -74,36 +75,29 @@ func deleteCommand() *cli.Command {
 			if !ctx.Bool("cluster") && !ctx.Bool("workergroup") {
 				return fmt.Errorf("error: one of `--cluster` or `--workergroup` must be set")
 			}
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
 			if ctx.Bool("cluster") {
-				_, err := client.DeleteShootCluster(ctx.String("gardener-domain"), ctx.String("cluster-name"), ctx.String("region"), ctx.String("project-id"))
+				_, err := client.DeleteShootCluster(ctx.Context, ctx.String("gardener-domain"), ctx.String("cluster-name"), ctx.String("region"), ctx.String("project-id"))
 				if err != nil {
-					re, ok := err.(*cleura.RequestAPIError)
-					if ok {
-						if re.StatusCode == 403 {
-							return fmt.Errorf("error: invalid token")
-						}
-					}
-					return err
+					return shootAPIError(ctx, err)
+				}
+				if ctx.Bool("wait") {
+					fmt.Printf("Cluster: `%s` is being deleted\n", ctx.String("cluster-name"))
+					return waitForOperation(ctx, client, cleura.WaitOptions{Deleted: true})
 				}
 				fmt.Printf("Cluster: `%s` is being deleted.\nPlease check operation status with `cleura shoot list` command\n", ctx.String("cluster-name"))
 			}
 			if ctx.Bool("workergroup") {
-				_, err := client.DeleteWorkerGroup(ctx.String("gardener-domain"), ctx.String("cluster-name"), ctx.String("region"), ctx.String("project-id"), ctx.String("wg-name"))
+				_, err := client.DeleteWorkerGroup(ctx.Context, ctx.String("gardener-domain"), ctx.String("cluster-name"), ctx.String("region"), ctx.String("project-id"), ctx.String("wg-name"))
 				if err != nil {
-					re, ok := err.(*cleura.RequestAPIError)
-					if ok {
-						if re.StatusCode == 403 {
-							return fmt.Errorf("error: invalid token")
-						}
-					}
-					return err
+					return workerGroupAPIError(ctx, ctx.String("wg-name"), err)
+				}
+				if ctx.Bool("wait") {
+					fmt.Printf("Workergroup: `%s` in cluster: `%s` is being deleted\n", ctx.String("wg-name"), ctx.String("cluster-name"))
+					return waitForOperation(ctx, client, cleura.WaitOptions{})
 				}
 				fmt.Printf("Workergroup: `%s` in cluster: `%s` is being deleted.\nPlease check operation status with `cleura shoot list` command\n", ctx.String("wg-name"), ctx.String("cluster-name"))
 			}
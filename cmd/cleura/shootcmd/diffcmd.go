@@ -0,0 +1,154 @@
+package shootcmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/manifest"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// Exit code of `diff` and `apply --plan` when live shoot clusters differ from manifests.
+const driftExitCode = 2
+
+func diffCommand() *cli.Command {
+	commonFlags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	commonFlags = append(commonFlags, skipValidationFlag(), noColorFlag())
+	return &cli.Command{
+		Name:        "diff",
+		Description: fmt.Sprintf("Compare live shoot clusters with manifests and list API calls `apply` would make. Exits with code %d if any shoot cluster differs from its manifest", driftExitCode),
+		Usage:       "Show differences between live shoot clusters and manifests",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			commonFlags,
+			&cli.StringSliceFlag{
+				Name:     "filename",
+				Aliases:  []string{"f"},
+				Usage:    "Manifest file or directory of manifests, - reads standard input. Can be repeated",
+				Required: true,
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			err := common.ValidateNotEmptyString(ctx,
+				"token",
+				"username",
+				"api-host",
+			)
+			if err != nil {
+				return err
+			}
+			shoots, err := manifest.Load(ctx.StringSlice("filename")...)
+			if err != nil {
+				return fmt.Errorf("error: %w", err)
+			}
+			client, err := common.CleuraClient(ctx)
+			if err != nil {
+				return err
+			}
+			profiles := newCloudProfileCache(client)
+			printer := newPlanPrinter(ctx)
+			drift := 0
+			for _, shoot := range shoots {
+				plan, err := planManifest(ctx, client, profiles, shoot)
+				if err != nil {
+					return err
+				}
+				printer.print(plan)
+				if plan.Drift() {
+					drift++
+				}
+			}
+			return driftError(drift)
+		},
+	}
+}
+
+func noColorFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "no-color",
+		Usage: "Disable colored output. Colors are also disabled if NO_COLOR is set or standard output is not a terminal",
+	}
+}
+
+// Exit with driftExitCode if any shoot cluster differs from its manifest.
+func driftError(drift int) error {
+	if drift == 0 {
+		return nil
+	}
+	return cli.Exit(fmt.Sprintf("%d shoot cluster(s) differ from manifests", drift), driftExitCode)
+}
+
+// Print field level differences and API calls of plans.
+type planPrinter struct {
+	color bool
+}
+
+func newPlanPrinter(ctx *cli.Context) *planPrinter {
+	return &planPrinter{
+		color: !ctx.Bool("no-color") && os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(os.Stdout.Fd())),
+	}
+}
+
+func (p *planPrinter) colorize(color text.Color, s string) string {
+	if !p.color {
+		return s
+	}
+	return color.Sprint(s)
+}
+
+func (p *planPrinter) print(plan *manifest.Plan) {
+	name := plan.Shoot.Metadata.Name
+	for _, warning := range plan.Warnings {
+		fmt.Println(p.colorize(text.FgHiRed, fmt.Sprintf("Warning: shoot `%s`: %s", name, warning)))
+	}
+	if plan.Empty() {
+		fmt.Printf("Cluster: `%s` in %s is up to date\n", name, plan.Location)
+		return
+	}
+	fmt.Printf("Cluster: `%s` in %s, %d action(s):\n", name, plan.Location, len(plan.Actions))
+	for _, action := range plan.Actions {
+		fmt.Println(p.colorize(actionColor(action.Type), fmt.Sprintf("%s %s", actionMarker(action.Type), action)))
+		for _, change := range action.Changes {
+			fmt.Printf("    %s\n", p.change(change))
+		}
+	}
+	fmt.Println("API calls:")
+	for i, action := range plan.Actions {
+		method, path := plan.Endpoint(action)
+		fmt.Printf("  %d. %-6s %s\n", i+1, method, path)
+	}
+}
+
+func (p *planPrinter) change(c manifest.Change) string {
+	switch {
+	case c.From == "":
+		return p.colorize(text.FgGreen, fmt.Sprintf("+ %s: %q", c.Field, c.To))
+	case c.To == "":
+		return p.colorize(text.FgRed, fmt.Sprintf("- %s: %q", c.Field, c.From))
+	}
+	return p.colorize(text.FgYellow, fmt.Sprintf("~ %s: %q -> %q", c.Field, c.From, c.To))
+}
+
+func actionMarker(t manifest.ActionType) string {
+	switch t {
+	case manifest.ActionCreate, manifest.ActionAddWorkerGroup:
+		return "+"
+	case manifest.ActionDeleteWorkerGroup:
+		return "-"
+	}
+	return "~"
+}
+
+func actionColor(t manifest.ActionType) text.Color {
+	switch t {
+	case manifest.ActionCreate, manifest.ActionAddWorkerGroup:
+		return text.FgGreen
+	case manifest.ActionDeleteWorkerGroup:
+		return text.FgRed
+	}
+	return text.FgYellow
+}
@@ -0,0 +1,65 @@
+package shootcmd
+
+import (
+	"fmt"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/urfave/cli/v2"
+)
+
+func enableHACommand() *cli.Command {
+	commonFlags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	commonFlags = append(commonFlags, waitFlags()...)
+	return &cli.Command{
+		Name:        "enable-ha",
+		Description: "Enable highly available control plane of specified shoot cluster. Failure tolerance of the control plane is chosen by the API. HA control plane can not be disabled once enabled",
+		Usage:       "Enable highly available control plane of specified shoot cluster",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			commonFlags,
+			&cli.StringFlag{
+				Name:     "cluster-name",
+				Category: "Basic cluster settings",
+				Usage:    "Name of a cluster (Required)",
+				Required: true,
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			err := common.ValidateNotEmptyString(ctx,
+				"token",
+				"username",
+				"api-host",
+				"region",
+				"project-id",
+				"gardener-domain",
+			)
+			if err != nil {
+				return err
+			}
+			client, err := common.CleuraClient(ctx)
+			if err != nil {
+				return err
+			}
+			shoot, err := client.GetShootCluster(ctx.Context, ctx.String("gardener-domain"), ctx.String("cluster-name"), ctx.String("region"), ctx.String("project-id"))
+			if err != nil {
+				return shootAPIError(ctx, err)
+			}
+			if current := shoot.Spec.ControlPlane.HighAvailability.FailureTolerance.Type; current != "" {
+				fmt.Printf("Cluster: `%s` already has HA control plane with %s failure tolerance\n", ctx.String("cluster-name"), current)
+				return nil
+			}
+			_, err = client.EnableHaControlPlane(ctx.Context, ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"), ctx.String("cluster-name"))
+			if err != nil {
+				return shootAPIError(ctx, err)
+			}
+			if ctx.Bool("wait") {
+				fmt.Printf("Cluster: `%s` HA control plane is being enabled\n", ctx.String("cluster-name"))
+				return waitForOperation(ctx, client, cleura.WaitOptions{})
+			}
+			fmt.Printf("Cluster: `%s` HA control plane is being enabled.\nPlease check status with `cleura shoot list` command\n", ctx.String("cluster-name"))
+			return nil
+		},
+	}
+}
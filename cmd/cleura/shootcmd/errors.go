@@ -0,0 +1,36 @@
+package shootcmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/urfave/cli/v2"
+)
+
+// Translate shoot API errors into messages that name the shoot location.
+func shootAPIError(ctx *cli.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, cleura.ErrNotFound):
+		return fmt.Errorf("error: shoot `%s` not found in project %s/region %s", ctx.String("cluster-name"), ctx.String("project-id"), ctx.String("region"))
+	case errors.Is(err, cleura.ErrConflict):
+		return fmt.Errorf("error: shoot `%s` in project %s/region %s is busy with another operation, try again later", ctx.String("cluster-name"), ctx.String("project-id"), ctx.String("region"))
+	}
+	return common.HandleAPIError(err)
+}
+
+// Translate errors of calls addressing a workergroup. Not found may refer to the shoot or to the
+// workergroup, message returned by the API tells which one if there is any.
+func workerGroupAPIError(ctx *cli.Context, workerGroup string, err error) error {
+	if !errors.Is(err, cleura.ErrNotFound) {
+		return shootAPIError(ctx, err)
+	}
+	var re *cleura.RequestAPIError
+	if errors.As(err, &re) && re.APIBody != nil && re.APIBody.Message != "" {
+		return fmt.Errorf("error: workergroup `%s` of shoot `%s`: %s", workerGroup, ctx.String("cluster-name"), re.APIBody.Message)
+	}
+	return fmt.Errorf("error: shoot `%s` or its workergroup `%s` not found in project %s/region %s", ctx.String("cluster-name"), workerGroup, ctx.String("project-id"), ctx.String("region"))
+}
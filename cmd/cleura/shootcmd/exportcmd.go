@@ -0,0 +1,94 @@
+package shootcmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/manifest"
+	"github.com/urfave/cli/v2"
+)
+
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "export",
+		Description: "Export live shoot clusters as manifests for `cleura shoot apply`. Exports a single cluster if --cluster-name is given, every cluster in the project and region otherwise",
+		Usage:       "Export live shoot clusters as manifests",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			append(common.CleuraAuthFlags(), common.LocationFlags()...),
+			&cli.StringFlag{
+				Name:  "cluster-name",
+				Usage: "Name of a cluster to export. Exports every cluster if not set",
+			},
+			&cli.StringFlag{
+				Name:    "output-dir",
+				Aliases: []string{"d"},
+				Usage:   "Write one <cluster-name>.yaml file per cluster into the directory. Manifests are written to standard output if not set",
+			},
+			&cli.BoolFlag{
+				Name:  "overwrite",
+				Usage: "Replace existing manifest files in --output-dir",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			err := common.ValidateNotEmptyString(ctx,
+				"token",
+				"username",
+				"api-host",
+				"region",
+				"project-id",
+			)
+			if err != nil {
+				return err
+			}
+			client, err := common.CleuraClient(ctx)
+			if err != nil {
+				return err
+			}
+			loc := cleura.ShootLocation{
+				GardenDomain: cmp.Or(ctx.String("gardener-domain"), cleura.DefaultGardenDomain),
+				Region:       ctx.String("region"),
+				ProjectID:    ctx.String("project-id"),
+			}
+			var shoots []cleura.ShootClusterResponse
+			if name := ctx.String("cluster-name"); name != "" {
+				shoot, err := client.GetShootCluster(ctx.Context, loc.GardenDomain, name, loc.Region, loc.ProjectID)
+				if err != nil {
+					return shootAPIError(ctx, err)
+				}
+				shoots = append(shoots, *shoot)
+			} else {
+				shoots, err = client.ListShootClusters(ctx.Context, loc.GardenDomain, loc.Region, loc.ProjectID)
+				if err != nil {
+					return common.HandleAPIError(err)
+				}
+				if len(shoots) == 0 {
+					return fmt.Errorf("error: no shoot clusters found in %s", loc)
+				}
+			}
+			manifests := make([]*manifest.Shoot, 0, len(shoots))
+			for i := range shoots {
+				manifests = append(manifests, manifest.Export(&shoots[i], loc))
+			}
+			dir := ctx.String("output-dir")
+			if dir == "" {
+				return manifest.Encode(os.Stdout, manifests...)
+			}
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("error: %w", err)
+			}
+			for _, m := range manifests {
+				path, err := manifest.WriteFile(dir, m, ctx.Bool("overwrite"))
+				if err != nil {
+					return fmt.Errorf("error: %w", err)
+				}
+				fmt.Printf("Cluster: `%s` exported to %s\n", m.Metadata.Name, path)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package shootcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/configfile"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/urfave/cli/v2"
+)
+
+func findCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "find",
+		Description: "Find shoot clusters by name or glob pattern (ex: \"prod-*\") in all accessible domains, projects and regions",
+		Usage:       "Find shoot clusters by name in all accessible projects and regions",
+		ArgsUsage:   "<name-or-glob>",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			common.CleuraAuthFlags(),
+			&cli.StringFlag{
+				Name:        "gardener-domain",
+				Category:    "Location settings",
+				Usage:       "Specify gardener domain, defaults to 'public'",
+				EnvVars:     []string{"CLEURA_API_GARDENER_DOMAIN"},
+				DefaultText: "public",
+			},
+			&cli.BoolFlag{
+				Name:  "raw",
+				Usage: "Output in raw json",
+			},
+			&cli.BoolFlag{
+				Name:  "save",
+				Usage: "Save location of the found cluster as defaults in the active configuration profile. Requires exactly one match",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return fmt.Errorf("error: expected exactly one argument: <name-or-glob>")
+			}
+			err := common.ValidateNotEmptyString(ctx,
+				"token",
+				"username",
+				"api-host",
+			)
+			if err != nil {
+				return err
+			}
+			client, err := common.CleuraClient(ctx)
+			if err != nil {
+				return err
+			}
+			pattern := ctx.Args().First()
+			found, err := client.FindShootClusters(ctx.Context, pattern, cleura.FanOutOptions{GardenDomain: ctx.String("gardener-domain")})
+			if err != nil {
+				return common.HandleAPIError(err)
+			}
+			for _, locErr := range found.Errors {
+				fmt.Fprintf(os.Stderr, "warning: failed to search %s: %s\n", locErr.Location, common.HandleAPIError(locErr.Err))
+			}
+			if len(found.Shoots) == 0 {
+				return fmt.Errorf("error: no shoot cluster matching `%s` found", pattern)
+			}
+			if ctx.Bool("raw") {
+				raw, err := json.MarshalIndent(found.Shoots, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(raw))
+			} else {
+				t := table.NewWriter()
+				t.Style().Format.Header = text.FormatTitle
+				t.AppendHeader(table.Row{"Cluster name", "Gardener\nDomain", "Domain ID", "Project ID", "Project name", "Region"})
+				for _, located := range found.Shoots {
+					l := located.Location
+					t.AppendRow(table.Row{located.Shoot.Metadata.Name, l.GardenDomain, l.DomainID, l.ProjectID, l.ProjectName, l.Region})
+				}
+				fmt.Println(t.Render())
+			}
+			if ctx.Bool("save") {
+				if len(found.Shoots) != 1 {
+					return fmt.Errorf("error: `--save` requires exactly one match, found %d", len(found.Shoots))
+				}
+				return saveLocation(ctx.String("config-path"), found.Shoots[0].Location)
+			}
+			return nil
+		},
+	}
+}
+
+// Save shoot location as defaults in the active configuration profile.
+func saveLocation(configPath string, location cleura.ShootLocation) error {
+	config, err := configfile.InitConfiguration(configPath)
+	if err != nil {
+		return err
+	}
+	fields := [][2]string{
+		{"domain-id", location.DomainID},
+		{"project-id", location.ProjectID},
+		{"region", location.Region},
+		{"gardener-domain", location.GardenDomain},
+	}
+	for _, field := range fields {
+		if err := config.SetProfileField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Saved %s as defaults of profile `%s`\n", location, config.GetActiveProfile())
+	return nil
+}
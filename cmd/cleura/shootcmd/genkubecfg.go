@@ -6,7 +6,6 @@ import (
 
 	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
-	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
 	"github.com/aztekas/cleura-client-go/pkg/configfile"
 	"github.com/urfave/cli/v2"
 )
@@ -50,15 +49,12 @@ func genKubeConfigCommand() *cli.Command {
 			if err != nil {
 				return err
 			}
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
 			body, err := client.GenerateKubeConfig(
+				ctx.Context,
 				ctx.String("gardener-domain"),
 				ctx.String("region"),
 				ctx.String("project-id"),
@@ -66,13 +62,7 @@ func genKubeConfigCommand() *cli.Command {
 				ctx.Int64("config-duration"),
 			)
 			if err != nil {
-				re, ok := err.(*cleura.RequestAPIError)
-				if ok {
-					if re.StatusCode == 403 {
-						return fmt.Errorf("error: invalid token")
-					}
-				}
-				return err
+				return shootAPIError(ctx, err)
 			}
 			var configContent interface{}
 			err = json.Unmarshal(body, &configContent)
@@ -11,6 +11,7 @@ import (
 
 func hibernateCommand() *cli.Command {
 	commonFlags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	commonFlags = append(commonFlags, waitFlags()...)
 	return &cli.Command{
 		Name:        "hibernate",
 		Description: "Hibernate specified shoot cluster",
@@ -37,17 +38,17 @@ func hibernateCommand() *cli.Command {
 			if err != nil {
 				return err
 			}
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
-			err = client.HibernateCluster(ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"), ctx.String("cluster-name"))
+			err = client.HibernateCluster(ctx.Context, ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"), ctx.String("cluster-name"))
 			if err != nil {
-				return err
+				return shootAPIError(ctx, err)
+			}
+			if ctx.Bool("wait") {
+				fmt.Printf("Cluster: `%s` is being hibernated\n", ctx.String("cluster-name"))
+				return waitForOperation(ctx, client, cleura.WaitOptions{})
 			}
 			fmt.Printf("Cluster: `%s` is being hibernated.\nPlease check status with `cleura shoot list` command\n", ctx.String("cluster-name"))
 			return nil
@@ -3,6 +3,8 @@ package shootcmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
@@ -25,36 +27,52 @@ func listCommand() *cli.Command {
 				Name:  "raw",
 				Usage: "Output in raw json",
 			},
+			&cli.BoolFlag{
+				Name:  "watch",
+				Usage: "Watch shoot clusters and print changes as they happen. Stop with Ctrl-C",
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Usage: "Poll interval for --watch",
+				Value: cleura.DefaultWaitInterval,
+			},
+			&cli.BoolFlag{
+				Name:  "all-projects",
+				Usage: "List shoot clusters in every accessible project. --project-id is ignored",
+			},
+			&cli.BoolFlag{
+				Name:  "all-regions",
+				Usage: "List shoot clusters in every region of the domain. --region is ignored",
+			},
 		),
 		Action: func(ctx *cli.Context) error {
-			err := common.ValidateNotEmptyString(ctx,
-				"token",
-				"username",
-				"api-host",
-				"region",
-				"project-id",
-				"gardener-domain",
-			)
+			required := []string{"token", "username", "api-host", "gardener-domain"}
+			if !ctx.Bool("all-regions") {
+				required = append(required, "region")
+			}
+			if !ctx.Bool("all-projects") {
+				required = append(required, "project-id")
+			}
+			err := common.ValidateNotEmptyString(ctx, required...)
 			if err != nil {
 				return err
 			}
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
-			clusterList, err := client.ListShootClusters(ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"))
-			if err != nil {
-				re, ok := err.(*cleura.RequestAPIError)
-				if ok {
-					if re.StatusCode == 403 {
-						return fmt.Errorf("error: invalid token")
-					}
+			if ctx.Bool("all-projects") || ctx.Bool("all-regions") {
+				if ctx.Bool("watch") {
+					return fmt.Errorf("error: --watch can not be combined with --all-projects or --all-regions")
 				}
-				return err
+				return listAllShootClusters(ctx, client)
+			}
+			if ctx.Bool("watch") {
+				return watchShootClusters(ctx, client)
+			}
+			clusterList, err := client.ListShootClusters(ctx.Context, ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"))
+			if err != nil {
+				return common.HandleAPIError(err)
 			}
 			if ctx.Bool("raw") {
 				raw, err := json.MarshalIndent(clusterList, "", "  ")
@@ -68,16 +86,7 @@ func listCommand() *cli.Command {
 				t.Style().Format.Header = text.FormatTitle
 				t.AppendHeader(table.Row{"Cluster name", "Kubernetes\nVersion", "Workers", "Hibernated?", "Status", "Last operation"})
 				for _, cluster := range clusterList {
-					var statuses string
-					for _, condition := range cluster.Status.Conditions {
-						statuses += fmt.Sprintf("%s : %s\n", condition.Type, condition.Status)
-					}
-					var workers string
-					for _, worker := range cluster.Spec.Provider.Workers {
-						workers += fmt.Sprintf("name: %s\ntype: %s\nimage: %s\nimage_version: %s\nmin_nodes: %d\nmax_nodes: %d\n\n", worker.Name, worker.Machine.Type, worker.Machine.Image.Name, worker.Machine.Image.Version, worker.Minimum, worker.Maximum)
-					}
-					lastOperation := fmt.Sprintf("progress: %d\nstate: %s\ntype: %s\n", cluster.Status.LastOperation.Progress, cluster.Status.LastOperation.State, cluster.Status.LastOperation.Type)
-					t.AppendRow(table.Row{cluster.Metadata.Name, cluster.Spec.Kubernetes.Version, workers, cluster.Status.Hibernated, statuses, lastOperation})
+					t.AppendRow(shootClusterRow(cluster))
 				}
 				fmt.Printf("Shoot clusters in:\n- Project: %s\n- Region: %s\n", ctx.String("project-id"), ctx.String("region"))
 				fmt.Println(t.Render())
@@ -86,3 +95,80 @@ func listCommand() *cli.Command {
 		},
 	}
 }
+
+// List shoot clusters in every project and/or region and print them in a single table.
+// Locations that could not be listed are reported after the table.
+func listAllShootClusters(ctx *cli.Context, client *cleura.Client) error {
+	opts := cleura.FanOutOptions{GardenDomain: ctx.String("gardener-domain")}
+	if !ctx.Bool("all-projects") {
+		opts.ProjectIDs = []string{ctx.String("project-id")}
+	}
+	if !ctx.Bool("all-regions") {
+		opts.Regions = []string{ctx.String("region")}
+	}
+	all, err := client.ListAllShootClusters(ctx.Context, opts)
+	if err != nil {
+		return common.HandleAPIError(err)
+	}
+	if ctx.Bool("raw") {
+		raw, err := json.MarshalIndent(all.Shoots, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(raw))
+	} else {
+		t := table.NewWriter()
+		t.SetAutoIndex(true)
+		t.Style().Format.Header = text.FormatTitle
+		t.AppendHeader(table.Row{"Project", "Region", "Cluster name", "Kubernetes\nVersion", "Workers", "Hibernated?", "Status", "Last operation"})
+		for _, located := range all.Shoots {
+			project := located.Location.ProjectName
+			if project == "" {
+				project = located.Location.ProjectID
+			}
+			t.AppendRow(append(table.Row{project, located.Location.Region}, shootClusterRow(located.Shoot)...))
+		}
+		fmt.Println(t.Render())
+	}
+	for _, locErr := range all.Errors {
+		fmt.Fprintf(os.Stderr, "warning: failed to list shoot clusters in %s: %s\n", locErr.Location, common.HandleAPIError(locErr.Err))
+	}
+	return nil
+}
+
+func shootClusterRow(cluster cleura.ShootClusterResponse) table.Row {
+	var statuses string
+	for _, condition := range cluster.Status.Conditions {
+		statuses += fmt.Sprintf("%s : %s\n", condition.Type, condition.Status)
+	}
+	var workers string
+	for _, worker := range cluster.Spec.Provider.Workers {
+		workers += fmt.Sprintf("name: %s\ntype: %s\nimage: %s\nimage_version: %s\nmin_nodes: %d\nmax_nodes: %d\n\n", worker.Name, worker.Machine.Type, worker.Machine.Image.Name, worker.Machine.Image.Version, worker.Minimum, worker.Maximum)
+	}
+	lastOperation := fmt.Sprintf("progress: %d\nstate: %s\ntype: %s\n", cluster.Status.LastOperation.Progress, cluster.Status.LastOperation.State, cluster.Status.LastOperation.Type)
+	return table.Row{cluster.Metadata.Name, cluster.Spec.Kubernetes.Version, workers, cluster.Status.Hibernated, statuses, lastOperation}
+}
+
+// Print shoot cluster events until interrupted.
+func watchShootClusters(ctx *cli.Context, client *cleura.Client) error {
+	fmt.Printf("Watching shoot clusters in:\n- Project: %s\n- Region: %s\n", ctx.String("project-id"), ctx.String("region"))
+	events := client.WatchShootClusters(ctx.Context, ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"), ctx.Duration("interval"))
+	for event := range events {
+		fmt.Println(formatShootEvent(event))
+	}
+	return nil
+}
+
+func formatShootEvent(event cleura.ShootEvent) string {
+	line := fmt.Sprintf("%s %-18s", event.Time.Format(time.RFC3339), event.Type)
+	switch event.Type {
+	case cleura.ShootWatchError:
+		return fmt.Sprintf("%s %s", line, common.HandleAPIError(event.Err))
+	case cleura.ShootConditionChanged:
+		return fmt.Sprintf("%s %s %s=%s %s", line, event.Name, event.Condition.Type, event.Condition.Status, event.Condition.Message)
+	case cleura.ShootHibernationChanged:
+		return fmt.Sprintf("%s %s hibernated=%t", line, event.Name, event.Shoot.Status.Hibernated)
+	}
+	op := event.Shoot.Status.LastOperation
+	return fmt.Sprintf("%s %s %s %s %d%%", line, event.Name, op.Type, op.State, op.Progress)
+}
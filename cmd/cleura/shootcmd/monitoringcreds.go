@@ -6,7 +6,6 @@ import (
 
 	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
 	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
-	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
 	"github.com/aztekas/cleura-client-go/pkg/configfile"
 	"github.com/urfave/cli/v2"
 )
@@ -44,28 +43,19 @@ func getMonitoringCredentialsCommand() *cli.Command {
 			if err != nil {
 				return err
 			}
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
 			body, err := client.GetMonitoringCredentials(
+				ctx.Context,
 				ctx.String("gardener-domain"),
 				ctx.String("region"),
 				ctx.String("project-id"),
 				ctx.String("cluster-name"),
 			)
 			if err != nil {
-				re, ok := err.(*cleura.RequestAPIError)
-				if ok {
-					if re.StatusCode == 403 {
-						return fmt.Errorf("error: invalid token")
-					}
-				}
-				return err
+				return shootAPIError(ctx, err)
 			}
 			var content interface{}
 			err = json.Unmarshal(body, &content)
@@ -12,7 +12,14 @@ func Command() *cli.Command {
 			getKubeConfigCommand(),
 			getMonitoringCredentialsCommand(),
 			listCommand(),
+			findCommand(),
 			createCommand(),
+			updateCommand(),
+			enableHACommand(),
+			workerGroupCommand(),
+			applyCommand(),
+			diffCommand(),
+			exportCommand(),
 			deleteCommand(),
 			hibernateCommand(),
 			wakeupCommand(),
@@ -0,0 +1,213 @@
+package shootcmd
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/manifest"
+	"github.com/urfave/cli/v2"
+)
+
+func updateCommand() *cli.Command {
+	commonFlags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	commonFlags = append(commonFlags, waitFlags()...)
+	commonFlags = append(commonFlags, skipValidationFlag(), noColorFlag())
+	return &cli.Command{
+		Name:        "update",
+		Description: "Update Kubernetes version, hibernation schedule and maintenance settings of a cluster. Only settings given by flags are changed",
+		Usage:       "Update cluster settings",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			commonFlags,
+			&cli.StringFlag{
+				Name:     "cluster-name",
+				Category: "Basic cluster settings",
+				Usage:    "Name of a cluster (Required)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "k8s-version",
+				Category: "Basic cluster settings",
+				Usage:    "Kubernetes version to upgrade to. Either a full version, latest (may be a preview version), latest-supported or <major>.<minor> for the latest usable patch version",
+			},
+			&cli.StringFlag{
+				Name:     "hibernation-start",
+				Category: "Hibernation settings",
+				Usage:    "Replace hibernation schedules with a single schedule, Start in cron format (ex: \"00 18 * * 1,2,3,4,5\")",
+			},
+			&cli.StringFlag{
+				Name:     "hibernation-end",
+				Category: "Hibernation settings",
+				Usage:    "Replace hibernation schedules with a single schedule, End in cron format (ex: \"00 08 * * 1,2,3,4,5\")",
+			},
+			&cli.BoolFlag{
+				Name:     "remove-hibernation-schedules",
+				Category: "Hibernation settings",
+				Usage:    "Remove all hibernation schedules",
+			},
+			&cli.StringFlag{
+				Name:     "maintenance-start",
+				Category: "Maintenance settings",
+				Usage:    "Maintenance schedule, Start in format 010000+0000, (e.g. 04:00:00 UTC)",
+			},
+			&cli.StringFlag{
+				Name:     "maintenance-end",
+				Category: "Maintenance settings",
+				Usage:    "Maintenance schedule, End in format 040000+0000, (e.g. 04:00:00 UTC)",
+			},
+			&cli.BoolFlag{
+				Name:     "allow-k8s-autoupdate",
+				Category: "Maintenance settings",
+				Usage:    "Toggle if automatic updates of kubernetes is allowed",
+			},
+			&cli.BoolFlag{
+				Name:     "allow-worker-image-autoupdate",
+				Category: "Maintenance settings",
+				Usage:    "Toggle if automatic updates of worker images is allowed",
+			},
+			&cli.BoolFlag{
+				Name:  "plan",
+				Usage: "Only show planned changes without applying them",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			err := common.ValidateNotEmptyString(ctx,
+				"token",
+				"username",
+				"api-host",
+				"region",
+				"project-id",
+			)
+			if err != nil {
+				return err
+			}
+			if err := checkUpdateFlags(ctx); err != nil {
+				return err
+			}
+			client, err := common.CleuraClient(ctx)
+			if err != nil {
+				return err
+			}
+			loc := cleura.ShootLocation{
+				GardenDomain: cmp.Or(ctx.String("gardener-domain"), cleura.DefaultGardenDomain),
+				Region:       ctx.String("region"),
+				ProjectID:    ctx.String("project-id"),
+			}
+			live, err := client.GetShootCluster(ctx.Context, loc.GardenDomain, ctx.String("cluster-name"), loc.Region, loc.ProjectID)
+			if err != nil {
+				return shootAPIError(ctx, err)
+			}
+			profile, err := requestCloudProfile(ctx, client, loc.GardenDomain, "k8s-version")
+			if err != nil {
+				return err
+			}
+			if err := resolveVersionFlags(ctx, profile, "k8s-version"); err != nil {
+				return err
+			}
+			desired := manifest.Export(live, loc)
+			mergeUpdateFlags(ctx, &desired.Spec)
+			plan, err := manifest.NewPlan(desired, loc, live)
+			if errors.Is(err, cleura.ErrInvalidRequest) {
+				return requestValidationError(ctx, desired.String(), err)
+			} else if err != nil {
+				return fmt.Errorf("error: %w", err)
+			}
+			// Only settings changed by flags are validated, so live values expired since do not block the update
+			if !ctx.Bool("skip-validation") {
+				if err := plan.Validate(profile); err != nil {
+					return requestValidationError(ctx, desired.String(), err)
+				}
+				if ctx.IsSet("k8s-version") {
+					if err := checkKubernetesUpgrade(profile, live.Spec.Kubernetes.Version, desired.Spec.Kubernetes.Version); err != nil {
+						return err
+					}
+				}
+			}
+			newPlanPrinter(ctx).print(plan)
+			if plan.Empty() || ctx.Bool("plan") {
+				return nil
+			}
+			return applyPlan(ctx, client, plan)
+		},
+	}
+}
+
+// Check that at least one setting is given and paired flags are set together.
+func checkUpdateFlags(ctx *cli.Context) error {
+	pairs := [][2]string{
+		{"hibernation-start", "hibernation-end"},
+		{"maintenance-start", "maintenance-end"},
+	}
+	for _, pair := range pairs {
+		if (ctx.String(pair[0]) == "") != (ctx.String(pair[1]) == "") {
+			return fmt.Errorf("error: both `--%s` and `--%s` flags must be supplied", pair[0], pair[1])
+		}
+	}
+	if ctx.IsSet("hibernation-start") && ctx.Bool("remove-hibernation-schedules") {
+		return fmt.Errorf("error: choose one of `--hibernation-start` or `--remove-hibernation-schedules`")
+	}
+	settings := []string{"k8s-version", "hibernation-start", "remove-hibernation-schedules", "maintenance-start", "allow-k8s-autoupdate", "allow-worker-image-autoupdate"}
+	for _, flag := range settings {
+		if ctx.IsSet(flag) {
+			return nil
+		}
+	}
+	return fmt.Errorf("error: nothing to update, set at least one of: --%s", strings.Join(settings, ", --"))
+}
+
+// Overwrite settings of the current spec with flags set by the user.
+func mergeUpdateFlags(ctx *cli.Context, spec *manifest.Spec) {
+	if ctx.IsSet("k8s-version") {
+		spec.Kubernetes.Version = ctx.String("k8s-version")
+	}
+	if ctx.Bool("remove-hibernation-schedules") {
+		spec.Hibernation = &manifest.Hibernation{Schedules: []manifest.HibernationSchedule{}}
+	}
+	if ctx.IsSet("hibernation-start") {
+		spec.Hibernation = &manifest.Hibernation{Schedules: []manifest.HibernationSchedule{{Start: ctx.String("hibernation-start"), End: ctx.String("hibernation-end")}}}
+	}
+	if spec.Maintenance == nil {
+		spec.Maintenance = &manifest.Maintenance{}
+	}
+	if ctx.IsSet("maintenance-start") {
+		spec.Maintenance.TimeWindow = &manifest.TimeWindow{Begin: ctx.String("maintenance-start"), End: ctx.String("maintenance-end")}
+	}
+	if ctx.IsSet("allow-k8s-autoupdate") || ctx.IsSet("allow-worker-image-autoupdate") {
+		if spec.Maintenance.AutoUpdate == nil {
+			spec.Maintenance.AutoUpdate = &manifest.AutoUpdate{}
+		}
+		if ctx.IsSet("allow-k8s-autoupdate") {
+			spec.Maintenance.AutoUpdate.KubernetesVersion = ctx.Bool("allow-k8s-autoupdate")
+		}
+		if ctx.IsSet("allow-worker-image-autoupdate") {
+			spec.Maintenance.AutoUpdate.MachineImageVersion = ctx.Bool("allow-worker-image-autoupdate")
+		}
+	}
+}
+
+// Kubernetes versions can only be upgraded one minor version at a time.
+func checkKubernetesUpgrade(profile *cleura.CloudProfile, from, to string) error {
+	if from == to {
+		return nil
+	}
+	upgradable, err := profile.Spec.UpgradableKubernetesVersions(from)
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+	var versions []string
+	for _, v := range upgradable {
+		if v.Version == to {
+			return nil
+		}
+		versions = append(versions, v.Version)
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("error: kubernetes version %s can not be upgraded to %s, no upgrades are offered", from, to)
+	}
+	return fmt.Errorf("error: kubernetes version %s can not be upgraded to %s, upgrades offered: %s\nUse --skip-validation to skip checks against the cloud profile", from, to, strings.Join(versions, ", "))
+}
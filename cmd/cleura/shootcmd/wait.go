@@ -0,0 +1,97 @@
+package shootcmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// Flags for commands starting asynchronous shoot operations.
+func waitFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:     "wait",
+			Category: "Wait settings",
+			Aliases:  []string{"w"},
+			Usage:    "Wait until the operation completes",
+		},
+		&cli.DurationFlag{
+			Name:     "timeout",
+			Category: "Wait settings",
+			Usage:    "Max time to wait for operation to complete with --wait",
+			Value:    30 * time.Minute,
+		},
+		&cli.DurationFlag{
+			Name:     "poll-interval",
+			Category: "Wait settings",
+			Usage:    "How often to poll operation status with --wait",
+			Value:    cleura.DefaultWaitInterval,
+		},
+	}
+}
+
+// Wait for operation on the shoot cluster set by `--cluster-name` showing progress on stderr.
+func waitForOperation(ctx *cli.Context, client *cleura.Client, opts cleura.WaitOptions) error {
+	opts.Timeout = ctx.Duration("timeout")
+	opts.Interval = ctx.Duration("poll-interval")
+	progress := newProgressPrinter(ctx.String("cluster-name"))
+	opts.Progress = progress.update
+	shoot, err := client.WaitForShootOperation(ctx.Context,
+		ctx.String("gardener-domain"),
+		ctx.String("region"),
+		ctx.String("project-id"),
+		ctx.String("cluster-name"),
+		opts,
+	)
+	progress.done()
+	if err != nil {
+		return shootAPIError(ctx, err)
+	}
+	if shoot == nil {
+		fmt.Printf("Cluster: `%s` is deleted\n", ctx.String("cluster-name"))
+		return nil
+	}
+	fmt.Printf("Cluster: `%s` %s operation %s\n", ctx.String("cluster-name"), shoot.Status.LastOperation.Type, strings.ToLower(shoot.Status.LastOperation.State))
+	return nil
+}
+
+// Print shoot operation progress. Redraws single line on terminals,
+// prints a line per change otherwise.
+type progressPrinter struct {
+	name     string
+	terminal bool
+	last     string
+}
+
+func newProgressPrinter(name string) *progressPrinter {
+	return &progressPrinter{
+		name:     name,
+		terminal: term.IsTerminal(int(os.Stderr.Fd())),
+	}
+}
+
+func (p *progressPrinter) update(shoot *cleura.ShootClusterResponse) {
+	op := shoot.Status.LastOperation
+	line := fmt.Sprintf("Waiting for `%s`: %s %s %d%%", p.name, op.Type, op.State, op.Progress)
+	if line == p.last {
+		return
+	}
+	if p.terminal {
+		fmt.Fprintf(os.Stderr, "\r\033[K%s", line)
+	} else {
+		fmt.Fprintln(os.Stderr, line)
+	}
+	p.last = line
+}
+
+func (p *progressPrinter) done() {
+	if p.terminal && p.last != "" {
+		fmt.Fprintln(os.Stderr)
+	}
+	p.last = ""
+}
@@ -11,6 +11,7 @@ import (
 
 func wakeupCommand() *cli.Command {
 	commonFlags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	commonFlags = append(commonFlags, waitFlags()...)
 	return &cli.Command{
 		Name:        "wakeup",
 		Description: "Wakeup specified shoot cluster",
@@ -36,17 +37,17 @@ func wakeupCommand() *cli.Command {
 			if err != nil {
 				return err
 			}
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
-			err = client.WakeUpCluster(ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"), ctx.String("cluster-name"))
+			err = client.WakeUpCluster(ctx.Context, ctx.String("gardener-domain"), ctx.String("region"), ctx.String("project-id"), ctx.String("cluster-name"))
 			if err != nil {
-				return err
+				return shootAPIError(ctx, err)
+			}
+			if ctx.Bool("wait") {
+				fmt.Printf("Cluster: `%s` is waking up\n", ctx.String("cluster-name"))
+				return waitForOperation(ctx, client, cleura.WaitOptions{RequireHealthy: true})
 			}
 			fmt.Printf("Cluster: `%s` will wake up soon.\nPlease check status with `cleura shoot list` command\n", ctx.String("cluster-name"))
 			return nil
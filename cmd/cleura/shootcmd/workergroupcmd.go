@@ -0,0 +1,324 @@
+package shootcmd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/cmd/cleura/configcmd"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/manifest"
+	"github.com/urfave/cli/v2"
+)
+
+func workerGroupCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "workergroup",
+		Description: "Change existing workergroups of a cluster",
+		Usage:       "Change existing workergroups of a cluster",
+		Subcommands: []*cli.Command{
+			workerGroupUpdateCommand(),
+			workerGroupScaleCommand(),
+		},
+	}
+}
+
+// Flags shared by workergroup subcommands.
+func workerGroupFlags() []cli.Flag {
+	flags := append(common.CleuraAuthFlags(), common.LocationFlags()...)
+	flags = append(flags, waitFlags()...)
+	return append(flags,
+		skipValidationFlag(),
+		noColorFlag(),
+		&cli.StringFlag{
+			Name:     "cluster-name",
+			Category: "Basic cluster settings",
+			Usage:    "Name of a cluster (Required)",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "wg-name",
+			Category: "Workergroup settings",
+			Usage:    "Workergroup name (Required)",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "plan",
+			Usage: "Only show planned changes without applying them",
+		},
+	)
+}
+
+func workerGroupUpdateCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "update",
+		Description: "Update settings of an existing workergroup. Only settings given by flags are changed",
+		Usage:       "Update settings of an existing workergroup",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			workerGroupFlags(),
+			&cli.IntFlag{
+				Name:     "wg-min",
+				Category: "Workergroup settings",
+				Usage:    "Autoscaler min nodes",
+			},
+			&cli.IntFlag{
+				Name:     "wg-max",
+				Category: "Workergroup settings",
+				Usage:    "Autoscaler max nodes",
+			},
+			&cli.IntFlag{
+				Name:     "wg-max-surge",
+				Category: "Workergroup settings",
+				Usage:    "Max number of nodes added above the desired count during rolling updates",
+			},
+			&cli.StringFlag{
+				Name:     "wg-type",
+				Category: "Workergroup settings",
+				Usage:    "Workergroup machine type",
+			},
+			&cli.StringFlag{
+				Name:     "wg-image-name",
+				Category: "Workergroup settings",
+				Usage:    "Workergroup image name, requires --wg-image-version",
+			},
+			&cli.StringFlag{
+				Name:     "wg-image-version",
+				Category: "Workergroup settings",
+				Usage:    "Workergroup image version. Either a full version, latest (may be a preview version), latest-supported or <major>.<minor> for the latest usable patch version",
+			},
+			&cli.StringSliceFlag{
+				Name:     "wg-label",
+				Category: "Workergroup settings",
+				Usage:    "Add or replace a label of the workergroup, can be set multiple times. supplied as key=value",
+			},
+			&cli.StringSliceFlag{
+				Name:     "wg-remove-label",
+				Category: "Workergroup settings",
+				Usage:    "Remove a label of the workergroup by key, can be set multiple times",
+			},
+			&cli.StringSliceFlag{
+				Name:     "wg-annotation",
+				Category: "Workergroup settings",
+				Usage:    "Add or replace an annotation of the workergroup, can be set multiple times. supplied as key=value",
+			},
+			&cli.StringSliceFlag{
+				Name:     "wg-remove-annotation",
+				Category: "Workergroup settings",
+				Usage:    "Remove an annotation of the workergroup by key, can be set multiple times",
+			},
+			&cli.StringSliceFlag{
+				Name:     "wg-taint",
+				Category: "Workergroup settings",
+				Usage:    "Add or replace a taint of the workergroup, can be set multiple times. supplied as key=value:effect",
+			},
+			&cli.StringSliceFlag{
+				Name:     "wg-remove-taint",
+				Category: "Workergroup settings",
+				Usage:    "Remove taints of the workergroup by key, can be set multiple times",
+			},
+			&cli.StringSliceFlag{
+				Name:     "wg-zone",
+				Category: "Workergroup settings",
+				Usage:    "Replace compute zones of the workergroup, can be set multiple times",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			return updateWorkerGroup(ctx, func(w *manifest.Worker) error {
+				return mergeWorkerGroupFlags(ctx, w)
+			})
+		},
+	}
+}
+
+func workerGroupScaleCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "scale",
+		Description: "Change autoscaler min and max nodes of an existing workergroup",
+		Usage:       "Change autoscaler bounds of an existing workergroup",
+		Before:      configcmd.TrySetConfigFromFile,
+		Flags: append(
+			workerGroupFlags(),
+			&cli.IntFlag{
+				Name:     "min",
+				Category: "Workergroup settings",
+				Usage:    "Autoscaler min nodes",
+			},
+			&cli.IntFlag{
+				Name:     "max",
+				Category: "Workergroup settings",
+				Usage:    "Autoscaler max nodes",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			if !ctx.IsSet("min") && !ctx.IsSet("max") {
+				return fmt.Errorf("error: at least one of `--min` or `--max` must be set")
+			}
+			return updateWorkerGroup(ctx, func(w *manifest.Worker) error {
+				if ctx.IsSet("min") {
+					w.Minimum = int16(ctx.Int("min"))
+				}
+				if ctx.IsSet("max") {
+					w.Maximum = int16(ctx.Int("max"))
+				}
+				return nil
+			})
+		},
+	}
+}
+
+// Fetch the cluster, apply `change` to workergroup `--wg-name`, show the difference and submit it.
+func updateWorkerGroup(ctx *cli.Context, change func(w *manifest.Worker) error) error {
+	err := common.ValidateNotEmptyString(ctx,
+		"token",
+		"username",
+		"api-host",
+		"region",
+		"project-id",
+		"gardener-domain",
+	)
+	if err != nil {
+		return err
+	}
+	client, err := common.CleuraClient(ctx)
+	if err != nil {
+		return err
+	}
+	loc := cleura.ShootLocation{
+		GardenDomain: ctx.String("gardener-domain"),
+		Region:       ctx.String("region"),
+		ProjectID:    ctx.String("project-id"),
+	}
+	live, err := client.GetShootCluster(ctx.Context, loc.GardenDomain, ctx.String("cluster-name"), loc.Region, loc.ProjectID)
+	if err != nil {
+		return shootAPIError(ctx, err)
+	}
+	desired := manifest.FromShootCluster(live, loc)
+	name := ctx.String("wg-name")
+	i := slices.IndexFunc(desired.Spec.Workers, func(w manifest.Worker) bool { return w.Name == name })
+	if i < 0 {
+		var names []string
+		for _, w := range desired.Spec.Workers {
+			names = append(names, w.Name)
+		}
+		return fmt.Errorf("error: workergroup `%s` not found in cluster `%s`, workergroups: %s", name, ctx.String("cluster-name"), strings.Join(names, ", "))
+	}
+	worker := &desired.Spec.Workers[i]
+	if err := change(worker); err != nil {
+		return err
+	}
+	var profile *cleura.CloudProfile
+	if !ctx.Bool("skip-validation") || cleura.IsVersionQuery(worker.Machine.Image.Version) {
+		if profile, err = requestCloudProfile(ctx, client, loc.GardenDomain, "wg-image-version"); err != nil {
+			return err
+		}
+		if err := desired.ResolveVersions(profile, time.Now()); err != nil {
+			return fmt.Errorf("error: %w", err)
+		}
+	}
+	subject := fmt.Sprintf("workergroup `%s` of shoot `%s`", name, ctx.String("cluster-name"))
+	plan, err := manifest.NewPlan(desired, loc, live)
+	if errors.Is(err, cleura.ErrInvalidRequest) {
+		return requestValidationError(ctx, subject, err)
+	} else if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+	// Only fields changed by the user are validated, so live values expired since do not block the update
+	if !ctx.Bool("skip-validation") {
+		if err := plan.Validate(profile); err != nil {
+			return requestValidationError(ctx, subject, err)
+		}
+	}
+	newPlanPrinter(ctx).print(plan)
+	if plan.Empty() || ctx.Bool("plan") {
+		return nil
+	}
+	return applyPlan(ctx, client, plan)
+}
+
+// Overwrite settings of the workergroup with `--wg-*` flags set by the user.
+func mergeWorkerGroupFlags(ctx *cli.Context, w *manifest.Worker) error {
+	changed := false
+	set := func(flag string) bool {
+		changed = changed || ctx.IsSet(flag)
+		return ctx.IsSet(flag)
+	}
+	if set("wg-min") {
+		w.Minimum = int16(ctx.Int("wg-min"))
+	}
+	if set("wg-max") {
+		w.Maximum = int16(ctx.Int("wg-max"))
+	}
+	if set("wg-max-surge") {
+		w.MaxSurge = int16(ctx.Int("wg-max-surge"))
+	}
+	if set("wg-type") {
+		w.Machine.Type = ctx.String("wg-type")
+	}
+	if set("wg-image-name") {
+		if !ctx.IsSet("wg-image-version") {
+			return fmt.Errorf("error: `--wg-image-version` must be supplied when changing `--wg-image-name`")
+		}
+		w.Machine.Image.Name = ctx.String("wg-image-name")
+	}
+	if set("wg-image-version") {
+		w.Machine.Image.Version = ctx.String("wg-image-version")
+	}
+	if set("wg-zone") {
+		w.Zones = ctx.StringSlice("wg-zone")
+	}
+	var err error
+	if set("wg-remove-label") || set("wg-label") {
+		if w.Labels, err = mergeKeyValues(w.Labels, ctx.StringSlice("wg-label"), ctx.StringSlice("wg-remove-label")); err != nil {
+			return fmt.Errorf("error: --wg-label: %w", err)
+		}
+	}
+	if set("wg-remove-annotation") || set("wg-annotation") {
+		if w.Annotations, err = mergeKeyValues(w.Annotations, ctx.StringSlice("wg-annotation"), ctx.StringSlice("wg-remove-annotation")); err != nil {
+			return fmt.Errorf("error: --wg-annotation: %w", err)
+		}
+	}
+	if set("wg-remove-taint") || set("wg-taint") {
+		w.Taints = slices.DeleteFunc(w.Taints, func(t manifest.Taint) bool {
+			return slices.Contains(ctx.StringSlice("wg-remove-taint"), t.Key)
+		})
+		for _, s := range ctx.StringSlice("wg-taint") {
+			taint, err := cleura.ParseTaint(s)
+			if err != nil {
+				return fmt.Errorf("error: --wg-taint: %w", err)
+			}
+			// Taints are identified by key and effect
+			w.Taints = slices.DeleteFunc(w.Taints, func(t manifest.Taint) bool {
+				return t.Key == taint.Key && t.Effect == taint.Effect
+			})
+			w.Taints = append(w.Taints, manifest.Taint{Key: taint.Key, Value: taint.Value, Effect: taint.Effect})
+		}
+	}
+	if !changed {
+		return fmt.Errorf("error: nothing to update, set at least one of the workergroup settings")
+	}
+	return nil
+}
+
+// Return copy of `current` with `remove` keys deleted and key=value pairs of `add` set.
+func mergeKeyValues(current map[string]string, add []string, remove []string) (map[string]string, error) {
+	merged := maps.Clone(current)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	for _, key := range remove {
+		delete(merged, key)
+	}
+	for _, s := range add {
+		kv, err := cleura.ParseKeyValue(s)
+		if err != nil {
+			return nil, err
+		}
+		merged[kv.Key] = kv.Value
+	}
+	return merged, nil
+}
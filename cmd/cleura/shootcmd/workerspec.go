@@ -0,0 +1,197 @@
+package shootcmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/manifest"
+	"github.com/urfave/cli/v2"
+)
+
+// Values of repeatable --workergroup-spec flag. Unlike cli.StringSliceFlag values are not split on commas.
+type workerGroupSpecs []string
+
+func (s *workerGroupSpecs) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func (s *workerGroupSpecs) String() string {
+	return strings.Join(*s, " ")
+}
+
+// Build workergroups from --workergroup-spec and --workers-file flags. Fields not set in a spec default
+// to --wg-* flags, labels, annotations and taints of --wg-* flags are added to those of the spec.
+// Returns nil if neither flag is set.
+func workerGroupsFromFlags(ctx *cli.Context) ([]manifest.Worker, error) {
+	var workers []manifest.Worker
+	if specs, ok := ctx.Generic("workergroup-spec").(*workerGroupSpecs); ok {
+		for _, spec := range *specs {
+			w, err := parseWorkerGroupSpec(ctx, spec)
+			if err != nil {
+				return nil, fmt.Errorf("error: --workergroup-spec %q: %w", spec, err)
+			}
+			workers = append(workers, w)
+		}
+	}
+	if path := ctx.String("workers-file"); path != "" {
+		fromFile, err := manifest.LoadWorkers(path)
+		if err != nil {
+			return nil, fmt.Errorf("error: --workers-file: %w", err)
+		}
+		workers = append(workers, fromFile...)
+	}
+	if len(workers) > 0 && ctx.IsSet("wg-name") {
+		return nil, fmt.Errorf("error: `--wg-name` can not be combined with `--workergroup-spec` or `--workers-file`, set name in each workergroup instead")
+	}
+	return workers, nil
+}
+
+// Parse comma separated key=value fields of a workergroup spec,
+// ex: "name=sys,type=b.4c8gb,min=2,max=4,zones=a;b,taint=k=v:NoSchedule".
+func parseWorkerGroupSpec(ctx *cli.Context, spec string) (manifest.Worker, error) {
+	w := manifest.Worker{
+		Machine: manifest.Machine{
+			Type:  ctx.String("wg-type"),
+			Image: manifest.Image{Name: ctx.String("wg-image-name"), Version: ctx.String("wg-image-version")},
+		},
+		Minimum: int16(ctx.Int("wg-min")),
+		Maximum: int16(ctx.Int("wg-max")),
+		Zones:   ctx.StringSlice("wg-zone"),
+	}
+	if volume := (manifest.Volume{Size: ctx.String("wg-volume-size"), Type: ctx.String("wg-volume-type")}); volume != (manifest.Volume{}) {
+		w.Volume = &volume
+	}
+	if err := setWorkerGroupFlagDefaults(ctx, &w); err != nil {
+		return w, err
+	}
+	imageVersionSet := false
+	for _, field := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return w, fmt.Errorf("expected key=value, got %q", field)
+		}
+		var err error
+		switch key {
+		case "name":
+			w.Name = value
+		case "type":
+			w.Machine.Type = value
+		case "image":
+			w.Machine.Image.Name = value
+		case "image-version":
+			w.Machine.Image.Version = value
+			imageVersionSet = true
+		case "min":
+			w.Minimum, err = parseCount(value)
+		case "max":
+			w.Maximum, err = parseCount(value)
+		case "max-surge":
+			w.MaxSurge, err = parseCount(value)
+		case "volume-size", "volume-type":
+			if w.Volume == nil {
+				w.Volume = &manifest.Volume{}
+			}
+			if key == "volume-size" {
+				w.Volume.Size = value
+			} else {
+				w.Volume.Type = value
+			}
+		case "zones":
+			w.Zones = strings.Split(value, ";")
+		case "label", "annotation":
+			var kv cleura.KeyValuePair
+			if kv, err = cleura.ParseKeyValue(value); err == nil && key == "label" {
+				w.Labels = setKeyValue(w.Labels, kv)
+			} else if err == nil {
+				w.Annotations = setKeyValue(w.Annotations, kv)
+			}
+		case "taint":
+			var taint cleura.Taint
+			if taint, err = cleura.ParseTaint(value); err == nil {
+				w.Taints = append(w.Taints, manifest.Taint{Key: taint.Key, Value: taint.Value, Effect: taint.Effect})
+			}
+		default:
+			return w, fmt.Errorf("unknown field %q, expected one of: name, type, image, image-version, min, max, max-surge, volume-size, volume-type, zones, label, annotation, taint", key)
+		}
+		if err != nil {
+			return w, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	if w.Name == "" {
+		return w, fmt.Errorf("name must be set")
+	}
+	// Version of --wg-image-name does not apply to other images
+	if !imageVersionSet && w.Machine.Image.Name != ctx.String("wg-image-name") {
+		w.Machine.Image.Version = cleura.VersionLatestSupported
+	}
+	return w, nil
+}
+
+// Add labels, annotations and taints of --wg-* flags to the workergroup. Spec fields add to them,
+// a label or annotation set in both takes the value of the spec.
+func setWorkerGroupFlagDefaults(ctx *cli.Context, w *manifest.Worker) error {
+	for _, s := range ctx.StringSlice("wg-label") {
+		kv, err := cleura.ParseKeyValue(s)
+		if err != nil {
+			return fmt.Errorf("--wg-label: %w", err)
+		}
+		w.Labels = setKeyValue(w.Labels, kv)
+	}
+	for _, s := range ctx.StringSlice("wg-annotation") {
+		kv, err := cleura.ParseKeyValue(s)
+		if err != nil {
+			return fmt.Errorf("--wg-annotation: %w", err)
+		}
+		w.Annotations = setKeyValue(w.Annotations, kv)
+	}
+	for _, s := range ctx.StringSlice("wg-taint") {
+		taint, err := cleura.ParseTaint(s)
+		if err != nil {
+			return fmt.Errorf("--wg-taint: %w", err)
+		}
+		w.Taints = append(w.Taints, manifest.Taint{Key: taint.Key, Value: taint.Value, Effect: taint.Effect})
+	}
+	return nil
+}
+
+func parseCount(s string) (int16, error) {
+	n, err := strconv.ParseInt(s, 10, 16)
+	return int16(n), err
+}
+
+func setKeyValue(m map[string]string, kv cleura.KeyValuePair) map[string]string {
+	if m == nil {
+		m = map[string]string{}
+	}
+	m[kv.Key] = kv.Value
+	return m
+}
+
+// Resolve image version queries of workergroups. Cloud profile is fetched if `profile` is nil and needed.
+func resolveWorkerVersions(ctx *cli.Context, client *cleura.Client, profile *cleura.CloudProfile, workers []manifest.Worker) (*cleura.CloudProfile, error) {
+	now := time.Now()
+	for i := range workers {
+		image := &workers[i].Machine.Image
+		if !cleura.IsVersionQuery(image.Version) {
+			continue
+		}
+		if profile == nil {
+			var err error
+			if profile, err = client.GetCloudProfile(ctx.Context, ctx.String("gardener-domain")); err != nil {
+				return nil, fmt.Errorf("error: fetching cloud profile failed: %w", common.HandleAPIError(err))
+			}
+		}
+		version, err := profile.Spec.ResolveMachineImageVersion(image.Name, image.Version, now)
+		if err != nil {
+			return nil, fmt.Errorf("error: resolving image version of workergroup `%s`: %w", workers[i].Name, err)
+		}
+		fmt.Printf("Resolved image version of workergroup `%s` `%s` to %s\n", workers[i].Name, image.Version, version)
+		image.Version = version
+	}
+	return profile, nil
+}
@@ -0,0 +1,190 @@
+package shootcmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/manifest"
+	"github.com/urfave/cli/v2"
+)
+
+// Parse `args` with flags of `shoot create` and return workergroups built from them.
+func workerGroupsFromArgs(t *testing.T, args ...string) ([]manifest.Worker, error) {
+	t.Helper()
+	var workers []manifest.Worker
+	var workersErr error
+	app := &cli.App{
+		Name:      "cleura",
+		Flags:     createCommand().Flags,
+		Writer:    io.Discard,
+		ErrWriter: io.Discard,
+		Action: func(ctx *cli.Context) error {
+			workers, workersErr = workerGroupsFromFlags(ctx)
+			return nil
+		},
+	}
+	if err := app.Run(append([]string{"cleura", "--cluster-name", "demo"}, args...)); err != nil {
+		t.Fatalf("parsing %q: %v", args, err)
+	}
+	return workers, workersErr
+}
+
+// Workergroup with defaults of --wg-* flags.
+func defaultWorker(name string) manifest.Worker {
+	return manifest.Worker{
+		Name:    name,
+		Machine: manifest.Machine{Type: "b.2c4gb", Image: manifest.Image{Name: "gardenlinux", Version: cleura.VersionLatestSupported}},
+		Minimum: 2,
+		Maximum: 3,
+		Volume:  &manifest.Volume{Size: "50Gi"},
+	}
+}
+
+func TestParseWorkerGroupSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    func() []manifest.Worker
+		wantErr string
+	}{
+		{
+			name: "defaults from wg flags",
+			args: []string{"--workergroup-spec", "name=sys"},
+			want: func() []manifest.Worker { return []manifest.Worker{defaultWorker("sys")} },
+		},
+		{
+			name: "wg flags override defaults",
+			args: []string{"--wg-type", "b.4c8gb", "--wg-min", "1", "--wg-zone", "nova", "--wg-image-version", "1592.4.0", "--workergroup-spec", "name=sys"},
+			want: func() []manifest.Worker {
+				w := defaultWorker("sys")
+				w.Machine.Type = "b.4c8gb"
+				w.Machine.Image.Version = "1592.4.0"
+				w.Minimum = 1
+				w.Zones = []string{"nova"}
+				return []manifest.Worker{w}
+			},
+		},
+		{
+			name: "every field",
+			args: []string{"--workergroup-spec", "name=gpu,type=g.8c32gb.a10,image=gardenlinux,image-version=1443.20.0,min=0,max=2,max-surge=1,volume-size=100Gi,volume-type=fast,zones=a;b,label=team=ml,label=tier=gpu,annotation=owner=ml,taint=nvidia.com/gpu=true:NoSchedule"},
+			want: func() []manifest.Worker {
+				return []manifest.Worker{{
+					Name:        "gpu",
+					Machine:     manifest.Machine{Type: "g.8c32gb.a10", Image: manifest.Image{Name: "gardenlinux", Version: "1443.20.0"}},
+					Minimum:     0,
+					Maximum:     2,
+					MaxSurge:    1,
+					Volume:      &manifest.Volume{Size: "100Gi", Type: "fast"},
+					Zones:       []string{"a", "b"},
+					Labels:      map[string]string{"team": "ml", "tier": "gpu"},
+					Annotations: map[string]string{"owner": "ml"},
+					Taints:      []manifest.Taint{{Key: "nvidia.com/gpu", Value: "true", Effect: "NoSchedule"}},
+				}}
+			},
+		},
+		{
+			name: "specs are not split on commas",
+			args: []string{"--workergroup-spec", "name=sys,min=1", "--workergroup-spec", "name=app,max=5"},
+			want: func() []manifest.Worker {
+				sys, app := defaultWorker("sys"), defaultWorker("app")
+				sys.Minimum = 1
+				app.Maximum = 5
+				return []manifest.Worker{sys, app}
+			},
+		},
+		{
+			name: "image version of wg flags applies to wg image only",
+			args: []string{"--wg-image-version", "1592.4.0", "--workergroup-spec", "name=sys,image=ubuntu"},
+			want: func() []manifest.Worker {
+				w := defaultWorker("sys")
+				w.Machine.Image = manifest.Image{Name: "ubuntu", Version: cleura.VersionLatestSupported}
+				return []manifest.Worker{w}
+			},
+		},
+		{
+			name: "label value may contain equal signs",
+			args: []string{"--workergroup-spec", "name=sys,label=query=a=b"},
+			want: func() []manifest.Worker {
+				w := defaultWorker("sys")
+				w.Labels = map[string]string{"query": "a=b"}
+				return []manifest.Worker{w}
+			},
+		},
+		{
+			name: "labels, annotations and taints of wg flags are added to spec",
+			args: []string{
+				"--wg-label", "team=ops", "--wg-label", "tier=sys", "--wg-annotation", "owner=ops", "--wg-taint", "dedicated=ops:NoSchedule",
+				"--workergroup-spec", "name=sys,label=tier=app,taint=spot=true:NoExecute",
+			},
+			want: func() []manifest.Worker {
+				w := defaultWorker("sys")
+				w.Labels = map[string]string{"team": "ops", "tier": "app"}
+				w.Annotations = map[string]string{"owner": "ops"}
+				w.Taints = []manifest.Taint{{Key: "dedicated", Value: "ops", Effect: "NoSchedule"}, {Key: "spot", Value: "true", Effect: "NoExecute"}}
+				return []manifest.Worker{w}
+			},
+		},
+		{name: "no workergroups", want: func() []manifest.Worker { return nil }},
+		{name: "missing name", args: []string{"--workergroup-spec", "type=b.4c8gb"}, wantErr: "name must be set"},
+		{name: "missing value", args: []string{"--workergroup-spec", "name=sys,min"}, wantErr: `expected key=value, got "min"`},
+		{name: "unknown field", args: []string{"--workergroup-spec", "name=sys,flavor=b.4c8gb"}, wantErr: `unknown field "flavor"`},
+		{name: "invalid count", args: []string{"--workergroup-spec", "name=sys,max=many"}, wantErr: "max: "},
+		{name: "count out of range", args: []string{"--workergroup-spec", "name=sys,max=40000"}, wantErr: "max: "},
+		{name: "invalid label", args: []string{"--workergroup-spec", "name=sys,label=team"}, wantErr: "label: expected key=value"},
+		{name: "invalid taint", args: []string{"--workergroup-spec", "name=sys,taint=k=v"}, wantErr: "taint: expected key=value:effect"},
+		{
+			name:    "combined with wg name",
+			args:    []string{"--wg-name", "other", "--workergroup-spec", "name=sys"},
+			wantErr: "`--wg-name` can not be combined",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := workerGroupsFromArgs(t, tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := tt.want(); !reflect.DeepEqual(got, want) {
+				t.Errorf("workergroups = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestWorkerGroupsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workers.yaml")
+	data := `- name: file
+  machine:
+    type: b.8c16gb
+    image:
+      name: gardenlinux
+      version: 1592.4.0
+  minimum: 1
+  maximum: 2
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := workerGroupsFromArgs(t, "--workergroup-spec", "name=sys", "--workers-file", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []manifest.Worker{
+		defaultWorker("sys"),
+		{Name: "file", Machine: manifest.Machine{Type: "b.8c16gb", Image: manifest.Image{Name: "gardenlinux", Version: "1592.4.0"}}, Minimum: 1, Maximum: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("workergroups = %+v, want %+v", got, want)
+	}
+}
@@ -95,7 +95,7 @@ func getCommand() *cli.Command {
 
 			// Handle two-factor authentication
 			if ctx.Bool("two-factor") {
-				client, err = cleura.NewClient(&host, &username, &password, true)
+				client, err = cleura.NewClient(ctx.Context, &host, &username, &password, true, cleura.WithLogger(logger))
 				if err != nil {
 					return err
 				}
@@ -107,23 +107,27 @@ func getCommand() *cli.Command {
 				if err != nil {
 					return err
 				}
-				err = client.GetTokenWith2FA(twoFaCode)
+				err = client.GetTokenWith2FA(ctx.Context, twoFaCode)
 				if err != nil {
 					return err
 				}
 			} else {
-				client, err = cleura.NewClient(&host, &username, &password, false)
+				client, err = cleura.NewClient(ctx.Context, &host, &username, &password, false, cleura.WithLogger(logger))
 				if err != nil {
 					return err
 				}
 			}
-			fmt.Printf("\nexport CLEURA_API_TOKEN=%v\nexport CLEURA_API_USERNAME=%v\nexport CLEURA_API_HOST=%v\n", client.Token, client.Auth.Username, ctx.String("api-host"))
+			token, err := client.AccessToken(ctx.Context)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nexport CLEURA_API_TOKEN=%v\nexport CLEURA_API_USERNAME=%v\nexport CLEURA_API_HOST=%v\n", token, client.Auth.Username, ctx.String("api-host"))
 			if ctx.Bool("update-config") {
 				config, err := configfile.InitConfiguration(ctx.String("config-path"))
 				if err != nil {
 					return fmt.Errorf("error updating configuration file: `%s`, %w", ctx.String("config-path"), err)
 				}
-				err = config.SetProfileField("token", client.Token)
+				err = config.SetProfileField("token", token)
 				if err != nil {
 					return err
 				}
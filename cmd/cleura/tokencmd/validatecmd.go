@@ -1,6 +1,7 @@
 package tokencmd
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/aztekas/cleura-client-go/cmd/cleura/common"
@@ -18,22 +19,15 @@ func validateCommand() *cli.Command {
 		Flags:       common.CleuraAuthFlags(),
 		Action: func(ctx *cli.Context) error {
 			logger := common.CliLogger(ctx.String("loglevel"))
-			token := ctx.String("token")
-			username := ctx.String("username")
-			host := ctx.String("api-host")
-			client, err := cleura.NewClientNoPassword(&host, &username, &token)
+			client, err := common.CleuraClient(ctx)
 			if err != nil {
 				return err
 			}
-			err = client.ValidateToken()
-			if err != nil {
-				re, ok := err.(*cleura.RequestAPIError)
-				if ok {
-					if re.StatusCode == 403 {
-						return fmt.Errorf("error: token is invalid or not supplied")
-					}
-				}
-				return err
+			err = client.ValidateToken(ctx.Context)
+			if errors.Is(err, cleura.ErrUnauthorized) {
+				return fmt.Errorf("error: token is invalid or not supplied")
+			} else if err != nil {
+				return common.HandleAPIError(err)
 			}
 			logger.Info("token is valid")
 			return nil
@@ -1,6 +1,7 @@
 package cleura
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,7 +9,7 @@ import (
 )
 
 // GetToken - Get a new token for the user.
-func (c *Client) GetToken() (*AuthResponse, error) {
+func (c *Client) GetToken(ctx context.Context) (*AuthResponse, error) {
 	if c.Auth.Username == "" || c.Auth.Password == "" {
 		return nil, fmt.Errorf("define username and password")
 	}
@@ -21,12 +22,12 @@ func (c *Client) GetToken() (*AuthResponse, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/auth/v1/tokens", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/auth/v1/tokens", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req, 200)
+	body, err := c.doRequestAuth(req, 200, authNone)
 	if err != nil {
 		return nil, err
 	}
@@ -42,13 +43,13 @@ func (c *Client) GetToken() (*AuthResponse, error) {
 }
 
 // RevokeToken - Revoke client token.
-func (c *Client) RevokeToken() error {
+func (c *Client) RevokeToken(ctx context.Context) error {
 	//https://rest.cleura.cloud/auth/v1/tokens
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/auth/v1/tokens", c.HostURL), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/auth/v1/tokens", c.HostURL), nil)
 	if err != nil {
 		return err
 	}
-	_, err = c.doRequest(req, 204)
+	_, err = c.doRequestAuth(req, 204, authToken)
 	if err != nil {
 		return err
 	}
@@ -56,14 +57,14 @@ func (c *Client) RevokeToken() error {
 }
 
 // Validate client token.
-func (c *Client) ValidateToken() error {
+func (c *Client) ValidateToken(ctx context.Context) error {
 	//https://rest.cleura.cloud/auth/v1/tokens/validate
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/auth/v1/tokens/validate", c.HostURL), nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/auth/v1/tokens/validate", c.HostURL), nil)
 	if err != nil {
 		return err
 	}
-	_, err = c.doRequest(req, 204)
+	_, err = c.doRequestAuth(req, 204, authToken)
 	if err != nil {
 		return err
 	}
@@ -71,7 +72,7 @@ func (c *Client) ValidateToken() error {
 }
 
 // Request verification code if 2-factor auth is enabled.
-func (c *Client) Request2FactorCode() error {
+func (c *Client) Request2FactorCode(ctx context.Context) error {
 	//https://rest.cleura.cloud/auth/v1/tokens
 	// get verification code
 	var authVerificationResult AuthVerificationResult
@@ -82,11 +83,11 @@ func (c *Client) Request2FactorCode() error {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/auth/v1/tokens", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/auth/v1/tokens", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
-	body, err := c.doRequest(req, 200)
+	body, err := c.doRequestAuth(req, 200, authNone)
 	if err != nil {
 		return err
 	}
@@ -105,11 +106,11 @@ func (c *Client) Request2FactorCode() error {
 		return err
 	}
 	//https://rest.cleura.cloud/auth/v1/tokens/request2facode
-	req, err = http.NewRequest("POST", fmt.Sprintf("%s/auth/v1/tokens/request2facode", c.HostURL), strings.NewReader(string(rb)))
+	req, err = http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/auth/v1/tokens/request2facode", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
-	_, err = c.doRequest(req, 204)
+	_, err = c.doRequestAuth(req, 204, authNone)
 	if err != nil {
 		return err
 	}
@@ -118,7 +119,7 @@ func (c *Client) Request2FactorCode() error {
 }
 
 // Issue token request with two-factor code received via sms.
-func (c *Client) GetTokenWith2FA(twoFACodeFromSms int) error {
+func (c *Client) GetTokenWith2FA(ctx context.Context, twoFACodeFromSms int) error {
 	var ar AuthResponse
 	verify2FaDetails := &AuthVerifyTwoFactorDetails{
 		Login:        c.Auth.Username,
@@ -133,11 +134,11 @@ func (c *Client) GetTokenWith2FA(twoFACodeFromSms int) error {
 		return err
 	}
 	//https://rest.cleura.cloud/auth/v1/tokens/verify2fa
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/auth/v1/tokens/verify2fa", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/auth/v1/tokens/verify2fa", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
-	jsonByte, err := c.doRequest(req, 200)
+	jsonByte, err := c.doRequestAuth(req, 200, authNone)
 	if err != nil {
 		return err
 	}
@@ -145,6 +146,6 @@ func (c *Client) GetTokenWith2FA(twoFACodeFromSms int) error {
 	if err != nil {
 		return err
 	}
-	c.Token = ar.Token
+	c.SetToken(ar.Token)
 	return nil
 }
@@ -0,0 +1,257 @@
+package cleura
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultFloatingPoolName - Floating IP pool used by shoot clusters.
+const DefaultFloatingPoolName = "ext-net"
+
+// ShootClusterBuilder builds ShootClusterRequest, allocating nested structs as needed.
+//
+//	req, err := cleura.NewShootClusterBuilder("demo").
+//		WithKubernetes("1.31.4").
+//		AddWorkerGroup(cleura.NewWorkerGroupBuilder("wg1").
+//			WithMachine("b.2c4gb", "gardenlinux", "1592.4.0").
+//			WithAutoscaling(2, 3)).
+//		WithMaintenanceWindow("000000+0000", "010000+0000").
+//		Build()
+type ShootClusterBuilder struct {
+	req     ShootClusterRequest
+	profile *CloudProfile
+	region  string
+}
+
+// NewShootClusterBuilder starts building request for shoot cluster `name`.
+func NewShootClusterBuilder(name string) *ShootClusterBuilder {
+	return &ShootClusterBuilder{
+		req: ShootClusterRequest{
+			Shoot: ShootClusterRequestConfig{
+				Name: name,
+				Provider: &ProviderDetailsRequest{
+					InfrastructureConfig: InfrastructureConfigDetails{
+						FloatingPoolName: DefaultFloatingPoolName,
+					},
+					Workers: []WorkerRequest{},
+				},
+			},
+		},
+	}
+}
+
+// WithKubernetes sets Kubernetes version.
+func (b *ShootClusterBuilder) WithKubernetes(version string) *ShootClusterBuilder {
+	b.req.Shoot.KubernetesVersion = &K8sVersion{Version: version}
+	return b
+}
+
+// WithPurpose sets shoot purpose, ex: "evaluation", "development" or "production".
+func (b *ShootClusterBuilder) WithPurpose(purpose string) *ShootClusterBuilder {
+	b.req.Shoot.Purpose = purpose
+	return b
+}
+
+// WithHAControlPlane enables highly available control plane. Failure tolerance is chosen by the API.
+func (b *ShootClusterBuilder) WithHAControlPlane() *ShootClusterBuilder {
+	b.req.Shoot.EnableHaControlPlane = true
+	return b
+}
+
+// AddWorkerGroup adds worker group built by `wg`.
+func (b *ShootClusterBuilder) AddWorkerGroup(wg *WorkerGroupBuilder) *ShootClusterBuilder {
+	b.req.Shoot.Provider.Workers = append(b.req.Shoot.Provider.Workers, wg.worker)
+	return b
+}
+
+// WithHibernationSchedule adds hibernation schedule with `start` and `end` in cron format,
+// ex: "00 18 * * 1,2,3,4,5".
+func (b *ShootClusterBuilder) WithHibernationSchedule(start, end string) *ShootClusterBuilder {
+	if b.req.Shoot.Hibernation == nil {
+		b.req.Shoot.Hibernation = &HibernationSchedules{}
+	}
+	b.req.Shoot.Hibernation.HibernationSchedules = append(b.req.Shoot.Hibernation.HibernationSchedules, HibernationSchedule{
+		Start: start,
+		End:   end,
+	})
+	return b
+}
+
+// WithMaintenanceWindow sets maintenance time window with `begin` and `end` formatted as
+// HHMMSS+ZZZZ, ex: "040000+0000".
+func (b *ShootClusterBuilder) WithMaintenanceWindow(begin, end string) *ShootClusterBuilder {
+	b.maintenance().TimeWindow = &TimeWindowDetails{Begin: begin, End: end}
+	return b
+}
+
+// WithAutoUpdate toggles automatic updates of Kubernetes and machine image versions during maintenance.
+func (b *ShootClusterBuilder) WithAutoUpdate(kubernetesVersion, machineImageVersion bool) *ShootClusterBuilder {
+	b.maintenance().AutoUpdate = &AutoUpdateDetails{
+		KubernetesVersion:   kubernetesVersion,
+		MachineImageVersion: machineImageVersion,
+	}
+	return b
+}
+
+func (b *ShootClusterBuilder) maintenance() *MaintenanceDetails {
+	if b.req.Shoot.Maintenance == nil {
+		b.req.Shoot.Maintenance = &MaintenanceDetails{}
+	}
+	return b.req.Shoot.Maintenance
+}
+
+// WithExistingNetwork attaches workers to an existing OpenStack network managed by router `routerID`.
+func (b *ShootClusterBuilder) WithExistingNetwork(networkID, routerID string) *ShootClusterBuilder {
+	network := b.networks()
+	network.Id = networkID
+	network.Router = Router{Id: routerID}
+	return b
+}
+
+// WithWorkersCIDR sets subnet CIDR used for worker nodes.
+func (b *ShootClusterBuilder) WithWorkersCIDR(cidr string) *ShootClusterBuilder {
+	b.networks().WorkersCIDR = cidr
+	return b
+}
+
+func (b *ShootClusterBuilder) networks() *WorkerNetwork {
+	infra := &b.req.Shoot.Provider.InfrastructureConfig
+	if infra.Networks == nil {
+		infra.Networks = &WorkerNetwork{}
+	}
+	return infra.Networks
+}
+
+// WithCloudProfile makes Build validate request against cloud profile for `region` as well.
+func (b *ShootClusterBuilder) WithCloudProfile(profile *CloudProfile, region string) *ShootClusterBuilder {
+	b.profile = profile
+	b.region = region
+	return b
+}
+
+// Build validates and returns the request. See ValidateShootClusterRequest.
+func (b *ShootClusterBuilder) Build() (ShootClusterRequest, error) {
+	if err := ValidateShootClusterRequest(b.req, b.profile, b.region); err != nil {
+		return ShootClusterRequest{}, err
+	}
+	return b.req, nil
+}
+
+// WorkerGroupBuilder builds WorkerGroupRequest or a worker group of ShootClusterBuilder.
+type WorkerGroupBuilder struct {
+	worker  WorkerRequest
+	profile *CloudProfile
+	region  string
+}
+
+// NewWorkerGroupBuilder starts building worker group `name`. Name is generated by the API if empty.
+func NewWorkerGroupBuilder(name string) *WorkerGroupBuilder {
+	return &WorkerGroupBuilder{
+		worker: WorkerRequest{
+			Name:        name,
+			Labels:      []KeyValuePair{},
+			Annotations: []KeyValuePair{},
+			Taints:      []Taint{},
+		},
+	}
+}
+
+// WithMachine sets machine type and image of worker nodes.
+func (b *WorkerGroupBuilder) WithMachine(machineType, imageName, imageVersion string) *WorkerGroupBuilder {
+	b.worker.Machine = MachineDetails{
+		Type: machineType,
+		Image: ImageDetails{
+			Name:    imageName,
+			Version: imageVersion,
+		},
+	}
+	return b
+}
+
+// WithAutoscaling sets min and max number of worker nodes.
+func (b *WorkerGroupBuilder) WithAutoscaling(minimum, maximum int16) *WorkerGroupBuilder {
+	b.worker.Minimum = minimum
+	b.worker.Maximum = maximum
+	return b
+}
+
+// WithMaxSurge sets max number of nodes created above maximum during rolling updates.
+func (b *WorkerGroupBuilder) WithMaxSurge(maxSurge int16) *WorkerGroupBuilder {
+	b.worker.MaxSurge = maxSurge
+	return b
+}
+
+// WithVolumeSize sets size of worker node volumes, ex: "50Gi".
+func (b *WorkerGroupBuilder) WithVolumeSize(size string) *WorkerGroupBuilder {
+	b.worker.Volume.Size = size
+	return b
+}
+
+// WithVolumeType sets type of worker node volumes. Zones listing the type as unavailable are rejected
+// by validation against the cloud profile.
+func (b *WorkerGroupBuilder) WithVolumeType(volumeType string) *WorkerGroupBuilder {
+	b.worker.Volume.Type = volumeType
+	return b
+}
+
+// WithZones sets compute zones of worker nodes.
+func (b *WorkerGroupBuilder) WithZones(zones ...string) *WorkerGroupBuilder {
+	b.worker.Zones = append(b.worker.Zones, zones...)
+	return b
+}
+
+// WithLabel adds label to worker nodes.
+func (b *WorkerGroupBuilder) WithLabel(key, value string) *WorkerGroupBuilder {
+	b.worker.Labels = append(b.worker.Labels, KeyValuePair{Key: key, Value: value})
+	return b
+}
+
+// WithAnnotation adds annotation to worker nodes.
+func (b *WorkerGroupBuilder) WithAnnotation(key, value string) *WorkerGroupBuilder {
+	b.worker.Annotations = append(b.worker.Annotations, KeyValuePair{Key: key, Value: value})
+	return b
+}
+
+// WithTaint adds taint to worker nodes. Effect is one of NoSchedule, PreferNoSchedule or NoExecute.
+func (b *WorkerGroupBuilder) WithTaint(key, value, effect string) *WorkerGroupBuilder {
+	b.worker.Taints = append(b.worker.Taints, Taint{Key: key, Value: value, Effect: effect})
+	return b
+}
+
+// WithCloudProfile makes Build validate request against cloud profile for `region` as well.
+func (b *WorkerGroupBuilder) WithCloudProfile(profile *CloudProfile, region string) *WorkerGroupBuilder {
+	b.profile = profile
+	b.region = region
+	return b
+}
+
+// Build validates and returns the request. See ValidateWorkerGroupRequest.
+func (b *WorkerGroupBuilder) Build() (WorkerGroupRequest, error) {
+	req := WorkerGroupRequest{Worker: b.worker}
+	if err := ValidateWorkerGroupRequest(req, b.profile, b.region); err != nil {
+		return WorkerGroupRequest{}, err
+	}
+	return req, nil
+}
+
+// ParseKeyValue parses "key=value" as used for labels and annotations.
+func ParseKeyValue(s string) (KeyValuePair, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return KeyValuePair{}, fmt.Errorf("expected key=value, got %q", s)
+	}
+	return KeyValuePair{Key: key, Value: value}, nil
+}
+
+// ParseTaint parses "key=value:effect".
+func ParseTaint(s string) (Taint, error) {
+	key, rest, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return Taint{}, fmt.Errorf("expected key=value:effect, got %q", s)
+	}
+	value, effect, ok := strings.Cut(rest, ":")
+	if !ok {
+		return Taint{}, fmt.Errorf("expected key=value:effect, got %q", s)
+	}
+	return Taint{Key: key, Value: value, Effect: effect}, nil
+}
@@ -0,0 +1,255 @@
+package cleura_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+func TestShootClusterBuilder(t *testing.T) {
+	req, err := cleura.NewShootClusterBuilder("demo").
+		WithKubernetes("1.31.4").
+		WithPurpose("production").
+		WithHAControlPlane().
+		AddWorkerGroup(cleura.NewWorkerGroupBuilder("sys").
+			WithMachine("b.2c4gb", "gardenlinux", "1592.4.0").
+			WithAutoscaling(1, 3)).
+		WithHibernationSchedule("00 18 * * 1,2,3,4,5", "").
+		WithMaintenanceWindow("220000+0100", "230000+0100").
+		WithAutoUpdate(false, true).
+		WithExistingNetwork("net-1", "router-1").
+		WithWorkersCIDR("10.250.0.0/16").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := cleura.ShootClusterRequestConfig{
+		Name:                 "demo",
+		EnableHaControlPlane: true,
+		Purpose:              "production",
+		KubernetesVersion:    &cleura.K8sVersion{Version: "1.31.4"},
+		Provider: &cleura.ProviderDetailsRequest{
+			InfrastructureConfig: cleura.InfrastructureConfigDetails{
+				FloatingPoolName: cleura.DefaultFloatingPoolName,
+				Networks:         &cleura.WorkerNetwork{Id: "net-1", Router: cleura.Router{Id: "router-1"}, WorkersCIDR: "10.250.0.0/16"},
+			},
+			Workers: []cleura.WorkerRequest{{
+				Name:        "sys",
+				Minimum:     1,
+				Maximum:     3,
+				Machine:     cleura.MachineDetails{Type: "b.2c4gb", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+				Labels:      []cleura.KeyValuePair{},
+				Annotations: []cleura.KeyValuePair{},
+				Taints:      []cleura.Taint{},
+			}},
+		},
+		Hibernation: &cleura.HibernationSchedules{HibernationSchedules: []cleura.HibernationSchedule{{Start: "00 18 * * 1,2,3,4,5"}}},
+		Maintenance: &cleura.MaintenanceDetails{
+			AutoUpdate: &cleura.AutoUpdateDetails{KubernetesVersion: false, MachineImageVersion: true},
+			TimeWindow: &cleura.TimeWindowDetails{Begin: "220000+0100", End: "230000+0100"},
+		},
+	}
+	if !reflect.DeepEqual(req.Shoot, want) {
+		t.Errorf("request = %+v, want %+v", req.Shoot, want)
+	}
+}
+
+func TestShootClusterBuilderValidation(t *testing.T) {
+	profile := testCloudProfile(t)
+	worker := func() *cleura.WorkerGroupBuilder {
+		return cleura.NewWorkerGroupBuilder("sys").WithMachine("b.2c4gb", "gardenlinux", "1592.4.0").WithAutoscaling(1, 3)
+	}
+	tests := []struct {
+		name    string
+		builder *cleura.ShootClusterBuilder
+		fields  []string
+	}{
+		{
+			name:    "valid against cloud profile",
+			builder: cleura.NewShootClusterBuilder("demo").WithKubernetes("1.31.4").AddWorkerGroup(worker()).WithCloudProfile(profile, cleuratest.DefaultRegion),
+		},
+		{
+			name:    "unknown version accepted without cloud profile",
+			builder: cleura.NewShootClusterBuilder("demo").WithKubernetes("1.99.0").AddWorkerGroup(worker()),
+		},
+		{
+			name:    "unknown version rejected with cloud profile",
+			builder: cleura.NewShootClusterBuilder("demo").WithKubernetes("1.99.0").AddWorkerGroup(worker()).WithCloudProfile(profile, cleuratest.DefaultRegion),
+			fields:  []string{"shoot.kubernetesVersion.version"},
+		},
+		{
+			name:    "missing workers and malformed maintenance window",
+			builder: cleura.NewShootClusterBuilder("demo").WithKubernetes("1.31.4").WithMaintenanceWindow("22:00", "230000+0100"),
+			fields:  []string{"shoot.maintenance.timeWindow.begin", "shoot.provider.workers"},
+		},
+		{
+			name:    "network without router",
+			builder: cleura.NewShootClusterBuilder("demo").WithKubernetes("1.31.4").AddWorkerGroup(worker()).WithExistingNetwork("net-1", ""),
+			fields:  []string{"shoot.provider.infrastructureConfig.networks"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			var fields []string
+			for _, problem := range validationProblems(t, err) {
+				fields = append(fields, problem.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("problems in %v, want %v (error: %v)", fields, tt.fields, err)
+			}
+		})
+	}
+}
+
+func TestWorkerGroupBuilder(t *testing.T) {
+	req, err := cleura.NewWorkerGroupBuilder("gpu").
+		WithMachine("g.8c32gb.a10", "gardenlinux", "1592.4.0").
+		WithAutoscaling(0, 2).
+		WithMaxSurge(1).
+		WithVolumeSize("100Gi").
+		WithZones("nova").
+		WithLabel("team", "ml").
+		WithAnnotation("owner", "ml@example.com").
+		WithTaint("nvidia.com/gpu", "true", "NoSchedule").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := cleura.WorkerRequest{
+		Name:        "gpu",
+		Minimum:     0,
+		Maximum:     2,
+		MaxSurge:    1,
+		Machine:     cleura.MachineDetails{Type: "g.8c32gb.a10", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+		Volume:      cleura.VolumeDetails{Size: "100Gi"},
+		Labels:      []cleura.KeyValuePair{{Key: "team", Value: "ml"}},
+		Annotations: []cleura.KeyValuePair{{Key: "owner", Value: "ml@example.com"}},
+		Taints:      []cleura.Taint{{Key: "nvidia.com/gpu", Value: "true", Effect: "NoSchedule"}},
+		Zones:       []string{"nova"},
+	}
+	if !reflect.DeepEqual(req.Worker, want) {
+		t.Errorf("request = %+v, want %+v", req.Worker, want)
+	}
+}
+
+func TestWorkerGroupBuilderValidation(t *testing.T) {
+	profile := testCloudProfile(t)
+	_, err := cleura.NewWorkerGroupBuilder("sys").
+		WithMachine("b.2c4gb", "gardenlinux", "1592.4.0").
+		WithAutoscaling(1, 3).
+		WithZones("nova", "nva").
+		WithCloudProfile(profile, cleuratest.DefaultRegion).
+		Build()
+	problems := validationProblems(t, err)
+	if len(problems) != 1 || problems[0].Field != "worker.zones" {
+		t.Errorf("problems = %+v, want single problem in worker.zones", problems)
+	}
+}
+
+// Requests built by the builders are accepted by the API and produce the requested shoot cluster.
+func TestBuildersCreateShootCluster(t *testing.T) {
+	api := cleuratest.NewAPI()
+	api.SetOperationDuration(0)
+	srv := cleuratest.NewServer(api)
+	defer srv.Close()
+	client, err := srv.CleuraClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	profile, err := client.GetCloudProfile(ctx, cleuratest.DefaultGardenDomain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := cleura.NewShootClusterBuilder("demo").
+		WithKubernetes("1.31.4").
+		AddWorkerGroup(cleura.NewWorkerGroupBuilder("sys").
+			WithMachine("b.2c4gb", "gardenlinux", "1592.4.0").
+			WithAutoscaling(1, 3).
+			WithLabel("team", "ops")).
+		WithMaintenanceWindow("220000+0100", "230000+0100").
+		WithCloudProfile(profile, cleuratest.DefaultRegion).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateShootCluster(ctx, cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, req); err != nil {
+		t.Fatal(err)
+	}
+	wg, err := cleura.NewWorkerGroupBuilder("gpu").
+		WithMachine("g.8c32gb.a10", "gardenlinux", "1592.4.0").
+		WithAutoscaling(0, 2).
+		WithTaint("nvidia.com/gpu", "true", "NoSchedule").
+		WithCloudProfile(profile, cleuratest.DefaultRegion).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AddWorkerGroup(ctx, cleuratest.DefaultGardenDomain, "demo", cleuratest.DefaultRegion, cleuratest.DefaultProjectID, wg); err != nil {
+		t.Fatal(err)
+	}
+	shoot, err := client.GetShootCluster(ctx, cleuratest.DefaultGardenDomain, "demo", cleuratest.DefaultRegion, cleuratest.DefaultProjectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shoot.Spec.Kubernetes.Version != "1.31.4" {
+		t.Errorf("kubernetes version = %s, want 1.31.4", shoot.Spec.Kubernetes.Version)
+	}
+	if window := shoot.Spec.Maintenance.TimeWindow; window == nil || *window != (cleura.TimeWindowDetails{Begin: "220000+0100", End: "230000+0100"}) {
+		t.Errorf("maintenance window = %+v, want 220000+0100-230000+0100", window)
+	}
+	workers := shoot.Spec.Provider.Workers
+	if len(workers) != 2 {
+		t.Fatalf("workers = %+v, want sys and gpu", workers)
+	}
+	if w := workers[0]; w.Name != "sys" || w.Minimum != 1 || w.Maximum != 3 || w.Labels["team"] != "ops" {
+		t.Errorf("worker sys = %+v", w)
+	}
+	if w := workers[1]; w.Name != "gpu" || w.Machine.Type != "g.8c32gb.a10" || !reflect.DeepEqual(w.Taints, wg.Worker.Taints) {
+		t.Errorf("worker gpu = %+v", w)
+	}
+}
+
+func TestParseKeyValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    cleura.KeyValuePair
+		wantErr bool
+	}{
+		{in: "team=ops", want: cleura.KeyValuePair{Key: "team", Value: "ops"}},
+		{in: "empty=", want: cleura.KeyValuePair{Key: "empty"}},
+		{in: "url=a=b", want: cleura.KeyValuePair{Key: "url", Value: "a=b"}},
+		{in: "team", wantErr: true},
+		{in: "=ops", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := cleura.ParseKeyValue(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseKeyValue(%q) = %+v, %v; want %+v, error %t", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseTaint(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    cleura.Taint
+		wantErr bool
+	}{
+		{in: "dedicated=ops:NoSchedule", want: cleura.Taint{Key: "dedicated", Value: "ops", Effect: "NoSchedule"}},
+		{in: "nvidia.com/gpu=:NoExecute", want: cleura.Taint{Key: "nvidia.com/gpu", Effect: "NoExecute"}},
+		{in: "dedicated=ops", wantErr: true},
+		{in: "dedicated:NoSchedule", wantErr: true},
+		{in: "=ops:NoSchedule", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := cleura.ParseTaint(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTaint(%q) = %+v, %v; want %+v, error %t", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Package cleuramock provides hand-written mocks of the service interfaces
+// implemented by cleura.Client.
+//
+//	shoots := &cleuramock.ShootService{
+//		ListShootClustersFunc: func(ctx context.Context, gardenDomain, region, project string) ([]cleura.ShootClusterResponse, error) {
+//			return []cleura.ShootClusterResponse{{Metadata: cleura.MetadataFieldsResponse{Name: "test"}}}, nil
+//		},
+//	}
+package cleuramock
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotImplemented is returned by mock methods without a function set.
+var ErrNotImplemented = errors.New("mock method not implemented")
+
+func notImplemented(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrNotImplemented)
+}
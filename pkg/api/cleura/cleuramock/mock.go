@@ -0,0 +1,221 @@
+package cleuramock
+
+import (
+	"context"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+// AuthService is a mock of cleura.AuthService. Methods call the function field of the same name
+// with Func suffix, or return ErrNotImplemented if it is not set.
+type AuthService struct {
+	GetTokenFunc           func(context.Context) (*cleura.AuthResponse, error)
+	RevokeTokenFunc        func(context.Context) error
+	ValidateTokenFunc      func(context.Context) error
+	Request2FactorCodeFunc func(context.Context) error
+	GetTokenWith2FAFunc    func(context.Context, int) error
+}
+
+var _ cleura.AuthService = (*AuthService)(nil)
+
+func (m *AuthService) GetToken(ctx context.Context) (*cleura.AuthResponse, error) {
+	if m.GetTokenFunc == nil {
+		return nil, notImplemented("GetToken")
+	}
+	return m.GetTokenFunc(ctx)
+}
+
+func (m *AuthService) RevokeToken(ctx context.Context) error {
+	if m.RevokeTokenFunc == nil {
+		return notImplemented("RevokeToken")
+	}
+	return m.RevokeTokenFunc(ctx)
+}
+
+func (m *AuthService) ValidateToken(ctx context.Context) error {
+	if m.ValidateTokenFunc == nil {
+		return notImplemented("ValidateToken")
+	}
+	return m.ValidateTokenFunc(ctx)
+}
+
+func (m *AuthService) Request2FactorCode(ctx context.Context) error {
+	if m.Request2FactorCodeFunc == nil {
+		return notImplemented("Request2FactorCode")
+	}
+	return m.Request2FactorCodeFunc(ctx)
+}
+
+func (m *AuthService) GetTokenWith2FA(ctx context.Context, twoFACodeFromSms int) error {
+	if m.GetTokenWith2FAFunc == nil {
+		return notImplemented("GetTokenWith2FA")
+	}
+	return m.GetTokenWith2FAFunc(ctx, twoFACodeFromSms)
+}
+
+// AccessControlService is a mock of cleura.AccessControlService. Methods call the function field of the same name
+// with Func suffix, or return ErrNotImplemented if it is not set.
+type AccessControlService struct {
+	ListDomainsFunc  func(context.Context) (*[]cleura.OpenstackDomain, error)
+	ListProjectsFunc func(context.Context, string) (*[]cleura.OpenstackProject, error)
+}
+
+var _ cleura.AccessControlService = (*AccessControlService)(nil)
+
+func (m *AccessControlService) ListDomains(ctx context.Context) (*[]cleura.OpenstackDomain, error) {
+	if m.ListDomainsFunc == nil {
+		return nil, notImplemented("ListDomains")
+	}
+	return m.ListDomainsFunc(ctx)
+}
+
+func (m *AccessControlService) ListProjects(ctx context.Context, domain_id string) (*[]cleura.OpenstackProject, error) {
+	if m.ListProjectsFunc == nil {
+		return nil, notImplemented("ListProjects")
+	}
+	return m.ListProjectsFunc(ctx, domain_id)
+}
+
+// ShootService is a mock of cleura.ShootService. Methods call the function field of the same name
+// with Func suffix, or return ErrNotImplemented if it is not set.
+type ShootService struct {
+	GetShootClusterFunc          func(context.Context, string, string, string, string) (*cleura.ShootClusterResponse, error)
+	ListShootClustersFunc        func(context.Context, string, string, string) ([]cleura.ShootClusterResponse, error)
+	CreateShootClusterFunc       func(context.Context, string, string, string, cleura.ShootClusterRequest) (*cleura.ShootClusterCreateResponse, error)
+	UpdateShootClusterFunc       func(context.Context, string, string, string, string, cleura.ShootClusterRequest) (*cleura.ShootClusterResponse, error)
+	DeleteShootClusterFunc       func(context.Context, string, string, string, string) (string, error)
+	EnableHaControlPlaneFunc     func(context.Context, string, string, string, string) (bool, error)
+	AddWorkerGroupFunc           func(context.Context, string, string, string, string, cleura.WorkerGroupRequest) (*cleura.ShootClusterResponse, error)
+	UpdateWorkerGroupFunc        func(context.Context, string, string, string, string, string, cleura.WorkerGroupRequest) (*cleura.ShootClusterResponse, error)
+	DeleteWorkerGroupFunc        func(context.Context, string, string, string, string, string) (*cleura.ShootClusterResponse, error)
+	HibernateClusterFunc         func(context.Context, string, string, string, string) error
+	WakeUpClusterFunc            func(context.Context, string, string, string, string) error
+	GenerateKubeConfigFunc       func(context.Context, string, string, string, string, int64) ([]byte, error)
+	GetKubeConfigFunc            func(context.Context, string, string, string, string) ([]byte, error)
+	GetMonitoringCredentialsFunc func(context.Context, string, string, string, string) ([]byte, error)
+}
+
+var _ cleura.ShootService = (*ShootService)(nil)
+
+func (m *ShootService) GetShootCluster(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (*cleura.ShootClusterResponse, error) {
+	if m.GetShootClusterFunc == nil {
+		return nil, notImplemented("GetShootCluster")
+	}
+	return m.GetShootClusterFunc(ctx, gardenDomain, clusterName, clusterRegion, clusterProject)
+}
+
+func (m *ShootService) ListShootClusters(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string) ([]cleura.ShootClusterResponse, error) {
+	if m.ListShootClustersFunc == nil {
+		return nil, notImplemented("ListShootClusters")
+	}
+	return m.ListShootClustersFunc(ctx, gardenDomain, clusterRegion, clusterProject)
+}
+
+func (m *ShootService) CreateShootCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, shootClusterRequest cleura.ShootClusterRequest) (*cleura.ShootClusterCreateResponse, error) {
+	if m.CreateShootClusterFunc == nil {
+		return nil, notImplemented("CreateShootCluster")
+	}
+	return m.CreateShootClusterFunc(ctx, gardenDomain, clusterRegion, clusterProject, shootClusterRequest)
+}
+
+func (m *ShootService) UpdateShootCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string, shootClusterUpdateRequest cleura.ShootClusterRequest) (*cleura.ShootClusterResponse, error) {
+	if m.UpdateShootClusterFunc == nil {
+		return nil, notImplemented("UpdateShootCluster")
+	}
+	return m.UpdateShootClusterFunc(ctx, gardenDomain, clusterRegion, clusterProject, clusterName, shootClusterUpdateRequest)
+}
+
+func (m *ShootService) DeleteShootCluster(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (string, error) {
+	if m.DeleteShootClusterFunc == nil {
+		return "", notImplemented("DeleteShootCluster")
+	}
+	return m.DeleteShootClusterFunc(ctx, gardenDomain, clusterName, clusterRegion, clusterProject)
+}
+
+func (m *ShootService) EnableHaControlPlane(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) (bool, error) {
+	if m.EnableHaControlPlaneFunc == nil {
+		return false, notImplemented("EnableHaControlPlane")
+	}
+	return m.EnableHaControlPlaneFunc(ctx, gardenDomain, clusterRegion, clusterProject, clusterName)
+}
+
+func (m *ShootService) AddWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerGroupRequest cleura.WorkerGroupRequest) (*cleura.ShootClusterResponse, error) {
+	if m.AddWorkerGroupFunc == nil {
+		return nil, notImplemented("AddWorkerGroup")
+	}
+	return m.AddWorkerGroupFunc(ctx, gardenDomain, clusterName, clusterRegion, clusterProject, workerGroupRequest)
+}
+
+func (m *ShootService) UpdateWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string, workerGroupRequest cleura.WorkerGroupRequest) (*cleura.ShootClusterResponse, error) {
+	if m.UpdateWorkerGroupFunc == nil {
+		return nil, notImplemented("UpdateWorkerGroup")
+	}
+	return m.UpdateWorkerGroupFunc(ctx, gardenDomain, clusterName, clusterRegion, clusterProject, workerName, workerGroupRequest)
+}
+
+func (m *ShootService) DeleteWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string) (*cleura.ShootClusterResponse, error) {
+	if m.DeleteWorkerGroupFunc == nil {
+		return nil, notImplemented("DeleteWorkerGroup")
+	}
+	return m.DeleteWorkerGroupFunc(ctx, gardenDomain, clusterName, clusterRegion, clusterProject, workerName)
+}
+
+func (m *ShootService) HibernateCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error {
+	if m.HibernateClusterFunc == nil {
+		return notImplemented("HibernateCluster")
+	}
+	return m.HibernateClusterFunc(ctx, gardenDomain, clusterRegion, clusterProject, clusterName)
+}
+
+func (m *ShootService) WakeUpCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error {
+	if m.WakeUpClusterFunc == nil {
+		return notImplemented("WakeUpCluster")
+	}
+	return m.WakeUpClusterFunc(ctx, gardenDomain, clusterRegion, clusterProject, clusterName)
+}
+
+func (m *ShootService) GenerateKubeConfig(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string, durationSeconds int64) ([]byte, error) {
+	if m.GenerateKubeConfigFunc == nil {
+		return nil, notImplemented("GenerateKubeConfig")
+	}
+	return m.GenerateKubeConfigFunc(ctx, gardenDomain, clusterRegion, clusterProject, clusterName, durationSeconds)
+}
+
+func (m *ShootService) GetKubeConfig(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) ([]byte, error) {
+	if m.GetKubeConfigFunc == nil {
+		return nil, notImplemented("GetKubeConfig")
+	}
+	return m.GetKubeConfigFunc(ctx, gardenDomain, clusterRegion, clusterProject, clusterName)
+}
+
+func (m *ShootService) GetMonitoringCredentials(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) ([]byte, error) {
+	if m.GetMonitoringCredentialsFunc == nil {
+		return nil, notImplemented("GetMonitoringCredentials")
+	}
+	return m.GetMonitoringCredentialsFunc(ctx, gardenDomain, clusterRegion, clusterProject, clusterName)
+}
+
+// CloudProfileService is a mock of cleura.CloudProfileService. Methods call the function field of the same name
+// with Func suffix, or return ErrNotImplemented if it is not set.
+type CloudProfileService struct {
+	GetCloudProfileFunc func(context.Context, string) (*cleura.CloudProfile, error)
+}
+
+var _ cleura.CloudProfileService = (*CloudProfileService)(nil)
+
+func (m *CloudProfileService) GetCloudProfile(ctx context.Context, gardenDomain string) (*cleura.CloudProfile, error) {
+	if m.GetCloudProfileFunc == nil {
+		return nil, notImplemented("GetCloudProfile")
+	}
+	return m.GetCloudProfileFunc(ctx, gardenDomain)
+}
+
+// Client is a mock of cleura.API.
+type Client struct {
+	AuthService
+	AccessControlService
+	ShootService
+	CloudProfileService
+}
+
+var _ cleura.API = (*Client)(nil)
@@ -0,0 +1,456 @@
+package cleuratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+const shootPath = "/gardener/v1/{gardenDomain}/shoot/{region}/{project}"
+
+func (a *API) routes() {
+	mux := http.NewServeMux()
+	// Auth
+	mux.HandleFunc("POST /auth/v1/tokens", a.login)
+	mux.HandleFunc("DELETE /auth/v1/tokens", a.authenticated(a.revokeToken))
+	mux.HandleFunc("POST /auth/v1/tokens/validate", a.authenticated(a.validateToken))
+	mux.HandleFunc("POST /auth/v1/tokens/request2facode", a.request2FACode)
+	mux.HandleFunc("POST /auth/v1/tokens/verify2fa", a.verify2FA)
+	// Access control
+	mux.HandleFunc("GET /accesscontrol/v1/openstack/domains", a.authenticated(a.listDomains))
+	mux.HandleFunc("GET /accesscontrol/v1/openstack/{domainID}/projects", a.authenticated(a.listProjects))
+	// Gardener
+	mux.HandleFunc("GET /gardener/v1/{gardenDomain}/cloudprofile", a.authenticated(a.getCloudProfile))
+	mux.HandleFunc("GET "+shootPath, a.authenticated(a.listShoots))
+	mux.HandleFunc("POST "+shootPath, a.authenticated(a.createShoot))
+	mux.HandleFunc("GET "+shootPath+"/{name}", a.authenticated(a.getShoot))
+	mux.HandleFunc("PUT "+shootPath+"/{name}", a.authenticated(a.updateShoot))
+	mux.HandleFunc("DELETE "+shootPath+"/{name}", a.authenticated(a.deleteShoot))
+	mux.HandleFunc("POST "+shootPath+"/{name}/enable-ha-control-plane", a.authenticated(a.enableHaControlPlane))
+	mux.HandleFunc("POST "+shootPath+"/{name}/worker", a.authenticated(a.addWorker))
+	mux.HandleFunc("PUT "+shootPath+"/{name}/worker/{worker}", a.authenticated(a.updateWorker))
+	mux.HandleFunc("DELETE "+shootPath+"/{name}/worker/{worker}", a.authenticated(a.deleteWorker))
+	mux.HandleFunc("POST "+shootPath+"/{name}/hibernate", a.authenticated(a.hibernate))
+	mux.HandleFunc("POST "+shootPath+"/{name}/wakeup", a.authenticated(a.wakeUp))
+	mux.HandleFunc("POST "+shootPath+"/{name}/adminkubeconfig", a.authenticated(a.adminKubeConfig))
+	mux.HandleFunc("GET "+shootPath+"/{name}/kubeconfig", a.authenticated(a.kubeConfig))
+	mux.HandleFunc("GET "+shootPath+"/{name}/monitoring", a.authenticated(a.monitoring))
+	a.mux = mux
+}
+
+// Write json response.
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Write Cleura style error response.
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]any{
+		"code":    status,
+		"message": fmt.Sprintf(format, args...),
+	})
+}
+
+// Decode json request body, writing bad request response on failure.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %s", err)
+		return false
+	}
+	return true
+}
+
+// Reject requests without valid X-AUTH-LOGIN/X-AUTH-TOKEN headers. Handler is called with API lock held.
+func (a *API) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		username, ok := a.tokens[r.Header.Get("X-AUTH-TOKEN")]
+		if !ok || username != r.Header.Get("X-AUTH-LOGIN") {
+			writeError(w, http.StatusForbidden, "invalid token")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (a *API) login(w http.ResponseWriter, r *http.Request) {
+	var req cleura.AuthStructWrapper
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	u, ok := a.users[req.Auth.Username]
+	if !ok || u.password != req.Auth.Password {
+		writeError(w, http.StatusForbidden, "invalid username or password")
+		return
+	}
+	if u.twoFactor {
+		verification := a.newID("verification-")
+		a.verifications[verification] = req.Auth.Username
+		writeJSON(w, http.StatusOK, cleura.AuthVerificationResult{
+			Result:       "twofactor_required",
+			Type:         "sms",
+			Verification: verification,
+		})
+		return
+	}
+	token := a.newID("token-")
+	a.tokens[token] = req.Auth.Username
+	writeJSON(w, http.StatusOK, cleura.AuthResponse{Result: "login_ok", Token: token})
+}
+
+func (a *API) request2FACode(w http.ResponseWriter, r *http.Request) {
+	var req cleura.AuthRequestTwoFactor
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.verifications[req.Request2FA.Verification] != req.Request2FA.Login {
+		writeError(w, http.StatusForbidden, "invalid verification")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) verify2FA(w http.ResponseWriter, r *http.Request) {
+	var req cleura.AuthVerifyTwoFactor
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.verifications[req.Verify2FA.Verification] != req.Verify2FA.Login || req.Verify2FA.Code != DefaultTwoFactorCode {
+		writeError(w, http.StatusForbidden, "invalid verification code")
+		return
+	}
+	delete(a.verifications, req.Verify2FA.Verification)
+	token := a.newID("token-")
+	a.tokens[token] = req.Verify2FA.Login
+	writeJSON(w, http.StatusOK, cleura.AuthResponse{Result: "login_ok", Token: token})
+}
+
+func (a *API) revokeToken(w http.ResponseWriter, r *http.Request) {
+	delete(a.tokens, r.Header.Get("X-AUTH-TOKEN"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) validateToken(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) listDomains(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, a.domains)
+}
+
+func (a *API) listProjects(w http.ResponseWriter, r *http.Request) {
+	domainID := r.PathValue("domainID")
+	projects := []cleura.OpenstackProject{}
+	for _, project := range a.projects {
+		if project.DomainId == domainID {
+			projects = append(projects, project)
+		}
+	}
+	writeJSON(w, http.StatusOK, projects)
+}
+
+func (a *API) getCloudProfile(w http.ResponseWriter, _ *http.Request) {
+	profile := CloudProfileAt(a.now())
+	if a.profile != nil {
+		profile = *a.profile
+	}
+	writeJSON(w, http.StatusOK, []cleura.CloudProfile{profile})
+}
+
+func requestShootKey(r *http.Request) shootKey {
+	return shootKey{
+		gardenDomain: r.PathValue("gardenDomain"),
+		region:       r.PathValue("region"),
+		project:      r.PathValue("project"),
+		name:         r.PathValue("name"),
+	}
+}
+
+// Return shoot addressed by request path, writing not found response if it does not exist.
+func (a *API) requestShoot(w http.ResponseWriter, r *http.Request) (*shoot, bool) {
+	key := requestShootKey(r)
+	s, ok := a.lookupShoot(key)
+	if !ok {
+		writeError(w, http.StatusNotFound, "shoot %s not found", key.name)
+	}
+	return s, ok
+}
+
+// Return shoot addressed by request path ready for a new operation,
+// writing not found or conflict response otherwise.
+func (a *API) requestIdleShoot(w http.ResponseWriter, r *http.Request) (*shoot, bool) {
+	s, ok := a.requestShoot(w, r)
+	if !ok {
+		return nil, false
+	}
+	if s.busy() {
+		writeError(w, http.StatusConflict, "shoot %s is busy with %s operation", s.state.Metadata.Name, s.op.opType)
+		return nil, false
+	}
+	return s, true
+}
+
+func (a *API) listShoots(w http.ResponseWriter, r *http.Request) {
+	key := requestShootKey(r)
+	names := []string{}
+	for k := range a.shoots {
+		if k.gardenDomain == key.gardenDomain && k.region == key.region && k.project == key.project {
+			names = append(names, k.name)
+		}
+	}
+	slices.Sort(names)
+	shoots := []cleura.ShootClusterResponse{}
+	for _, name := range names {
+		key.name = name
+		if s, ok := a.lookupShoot(key); ok {
+			shoots = append(shoots, s.state)
+		}
+	}
+	writeJSON(w, http.StatusOK, shoots)
+}
+
+func (a *API) createShoot(w http.ResponseWriter, r *http.Request) {
+	var req cleura.ShootClusterRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	key := requestShootKey(r)
+	key.name = req.Shoot.Name
+	if key.name == "" {
+		writeError(w, http.StatusBadRequest, "shoot name is required")
+		return
+	}
+	if _, exists := a.lookupShoot(key); exists {
+		writeError(w, http.StatusConflict, "shoot %s already exists", key.name)
+		return
+	}
+	if req.Shoot.Provider == nil || len(req.Shoot.Provider.Workers) == 0 {
+		writeError(w, http.StatusBadRequest, "at least one worker group is required")
+		return
+	}
+	s := &shoot{state: a.newShootState(key.region, req.Shoot)}
+	a.shoots[key] = s
+	a.startOperation(s, &operation{opType: operationCreate})
+	writeJSON(w, http.StatusOK, createResponse(s.state))
+}
+
+func (a *API) getShoot(w http.ResponseWriter, r *http.Request) {
+	s, ok := a.requestShoot(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.state)
+}
+
+func (a *API) updateShoot(w http.ResponseWriter, r *http.Request) {
+	var req cleura.ShootClusterRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	s, ok := a.requestIdleShoot(w, r)
+	if !ok {
+		return
+	}
+	a.applyShootUpdate(&s.state, req.Shoot)
+	a.startOperation(s, &operation{opType: operationReconcile})
+	writeJSON(w, http.StatusAccepted, s.state)
+}
+
+func (a *API) deleteShoot(w http.ResponseWriter, r *http.Request) {
+	s, ok := a.requestShoot(w, r)
+	if !ok {
+		return
+	}
+	if s.op != nil && s.op.deletes {
+		writeError(w, http.StatusConflict, "shoot %s is already being deleted", s.state.Metadata.Name)
+		return
+	}
+	a.startOperation(s, &operation{opType: operationDelete, deletes: true})
+	writeJSON(w, http.StatusAccepted, fmt.Sprintf("shoot %s is being deleted", s.state.Metadata.Name))
+}
+
+func (a *API) enableHaControlPlane(w http.ResponseWriter, r *http.Request) {
+	s, ok := a.requestIdleShoot(w, r)
+	if !ok {
+		return
+	}
+	if s.state.Spec.ControlPlane.HighAvailability.FailureTolerance.Type != "" {
+		writeError(w, http.StatusConflict, "high availability control plane is already enabled for shoot %s", s.state.Metadata.Name)
+		return
+	}
+	a.startOperation(s, &operation{
+		opType: operationReconcile,
+		finish: func(s *shoot) {
+			s.state.Spec.ControlPlane.HighAvailability.FailureTolerance.Type = cleura.FailureToleranceNode
+		},
+	})
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *API) addWorker(w http.ResponseWriter, r *http.Request) {
+	var req cleura.WorkerGroupRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	s, ok := a.requestIdleShoot(w, r)
+	if !ok {
+		return
+	}
+	for _, worker := range s.state.Spec.Provider.Workers {
+		if req.Worker.Name != "" && worker.Name == req.Worker.Name {
+			writeError(w, http.StatusConflict, "worker group %s already exists", req.Worker.Name)
+			return
+		}
+	}
+	s.state.Spec.Provider.Workers = append(s.state.Spec.Provider.Workers, a.newWorker(req.Worker))
+	a.startOperation(s, &operation{opType: operationReconcile})
+	writeJSON(w, http.StatusAccepted, s.state)
+}
+
+func (a *API) updateWorker(w http.ResponseWriter, r *http.Request) {
+	var req cleura.WorkerGroupRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	s, ok := a.requestIdleShoot(w, r)
+	if !ok {
+		return
+	}
+	i := slices.IndexFunc(s.state.Spec.Provider.Workers, func(worker cleura.WorkerUpdateResponse) bool {
+		return worker.Name == r.PathValue("worker")
+	})
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "worker group %s not found", r.PathValue("worker"))
+		return
+	}
+	req.Worker.Name = r.PathValue("worker")
+	s.state.Spec.Provider.Workers[i] = a.newWorker(req.Worker)
+	a.startOperation(s, &operation{opType: operationReconcile})
+	writeJSON(w, http.StatusAccepted, s.state)
+}
+
+func (a *API) deleteWorker(w http.ResponseWriter, r *http.Request) {
+	s, ok := a.requestIdleShoot(w, r)
+	if !ok {
+		return
+	}
+	workers := s.state.Spec.Provider.Workers
+	i := slices.IndexFunc(workers, func(worker cleura.WorkerUpdateResponse) bool {
+		return worker.Name == r.PathValue("worker")
+	})
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "worker group %s not found", r.PathValue("worker"))
+		return
+	}
+	if len(workers) == 1 {
+		writeError(w, http.StatusBadRequest, "last worker group can not be deleted")
+		return
+	}
+	s.state.Spec.Provider.Workers = slices.Delete(workers, i, i+1)
+	a.startOperation(s, &operation{opType: operationReconcile})
+	writeJSON(w, http.StatusAccepted, s.state)
+}
+
+func (a *API) hibernate(w http.ResponseWriter, r *http.Request) {
+	a.setHibernation(w, r, true)
+}
+
+func (a *API) wakeUp(w http.ResponseWriter, r *http.Request) {
+	a.setHibernation(w, r, false)
+}
+
+func (a *API) setHibernation(w http.ResponseWriter, r *http.Request, hibernated bool) {
+	s, ok := a.requestIdleShoot(w, r)
+	if !ok {
+		return
+	}
+	if s.state.Status.Hibernated == hibernated {
+		writeError(w, http.StatusConflict, "shoot %s is already in requested hibernation state", s.state.Metadata.Name)
+		return
+	}
+	s.state.Spec.Hibernation.Enabled = hibernated
+	a.startOperation(s, &operation{
+		opType: operationReconcile,
+		finish: func(s *shoot) {
+			s.state.Status.Hibernated = hibernated
+		},
+	})
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *API) adminKubeConfig(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Config struct {
+			ExpirationSeconds int64 `json:"expirationSeconds"`
+		} `json:"config"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	s, ok := a.requestShoot(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, fakeKubeConfig(s, "admin"))
+}
+
+func (a *API) kubeConfig(w http.ResponseWriter, r *http.Request) {
+	s, ok := a.requestShoot(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, fakeKubeConfig(s, "user"))
+}
+
+func (a *API) monitoring(w http.ResponseWriter, r *http.Request) {
+	s, ok := a.requestShoot(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"username": "admin",
+		"password": "fake-monitoring-password",
+		"url":      fmt.Sprintf("https://gu-%s.fake.cleura.test", s.state.Metadata.Name),
+	})
+}
+
+// Return kubeconfig pointing to non-existing shoot API server.
+func fakeKubeConfig(s *shoot, user string) string {
+	server := ""
+	if len(s.state.Status.AdvertisedAddresses) > 0 {
+		server = s.state.Status.AdvertisedAddresses[0].Url
+	}
+	name := s.state.Metadata.Name
+	return strings.Join([]string{
+		"apiVersion: v1",
+		"kind: Config",
+		"clusters:",
+		"- name: " + name,
+		"  cluster:",
+		"    server: " + server,
+		"contexts:",
+		"- name: " + name,
+		"  context:",
+		"    cluster: " + name,
+		"    user: " + user,
+		"current-context: " + name,
+		"users:",
+		"- name: " + user,
+		"  user:",
+		"    token: fake-" + user + "-token",
+		"",
+	}, "\n")
+}
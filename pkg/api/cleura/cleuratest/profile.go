@@ -0,0 +1,65 @@
+package cleuratest
+
+import (
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+// DefaultCloudProfile returns cloud profile served by NewAPI with expiration dates relative to the current time.
+func DefaultCloudProfile() cleura.CloudProfile {
+	return CloudProfileAt(time.Now())
+}
+
+// CloudProfileAt returns cloud profile served by NewAPI at time `now`. Some deprecated versions expire
+// in the future and some have already expired, whatever the clock of the server says.
+func CloudProfileAt(now time.Time) cleura.CloudProfile {
+	expires := func(months int) string {
+		return now.UTC().AddDate(0, months, 0).Format(time.RFC3339)
+	}
+	return cleura.CloudProfile{
+		Name: "cleuracloud",
+		Spec: cleura.CloudProfileSpec{
+			Kubernetes: cleura.CPKubernetes{
+				Versions: []cleura.CPVersion{
+					{Version: "1.32.1", Classification: "preview"},
+					{Version: "1.31.4", Classification: "supported"},
+					{Version: "1.31.2", Classification: "deprecated", ExpirationDate: expires(6)},
+					{Version: "1.30.8", Classification: "supported"},
+					{Version: "1.30.5", Classification: "deprecated", ExpirationDate: expires(3)},
+					{Version: "1.29.12", Classification: "deprecated", ExpirationDate: expires(-3)},
+					{Version: "1.28.8", Classification: "deprecated", ExpirationDate: expires(-12)},
+				},
+			},
+			MachineImages: []cleura.CPMachineImage{
+				{
+					Name: "gardenlinux",
+					Versions: []cleura.CPVersion{
+						{Version: "1592.4.0", Classification: "supported"},
+						{Version: "1443.20.0", Classification: "supported"},
+						{Version: "1443.10.0", Classification: "deprecated", ExpirationDate: expires(3)},
+						{Version: "1312.3.0", Classification: "deprecated", ExpirationDate: expires(-12)},
+					},
+				},
+			},
+			MachineTypes: []cleura.CPMachineType{
+				{Name: "b.2c4gb", Cpu: "2", Memory: "4Gi", Gpu: "0", Usable: true, Architecture: "amd64"},
+				{Name: "b.4c8gb", Cpu: "4", Memory: "8Gi", Gpu: "0", Usable: true, Architecture: "amd64"},
+				{Name: "b.8c16gb", Cpu: "8", Memory: "16Gi", Gpu: "0", Usable: true, Architecture: "amd64"},
+				{Name: "m.4c32gb", Cpu: "4", Memory: "32Gi", Gpu: "0", Usable: true, Architecture: "amd64"},
+				{Name: "g.8c32gb.a10", Cpu: "8", Memory: "32Gi", Gpu: "1", Usable: true, Architecture: "amd64"},
+				{Name: "b.1c2gb", Cpu: "1", Memory: "2Gi", Gpu: "0", Usable: false, Architecture: "amd64"},
+			},
+			Regions: []cleura.CPRegion{
+				{
+					Name:  "sto2",
+					Zones: []cleura.CPZone{{Name: "nova"}},
+				},
+				{
+					Name:  "kna1",
+					Zones: []cleura.CPZone{{Name: "nova", UnavailableVolumeTypes: []string{"fast"}}},
+				},
+			},
+		},
+	}
+}
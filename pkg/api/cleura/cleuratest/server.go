@@ -0,0 +1,208 @@
+// Package cleuratest provides an in-memory fake of the Cleura API for tests
+// and local development.
+//
+//	srv := cleuratest.NewServer(nil)
+//	defer srv.Close()
+//	client, err := srv.CleuraClient()
+package cleuratest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+// Defaults seeded by NewAPI.
+const (
+	DefaultUsername          = "demo"
+	DefaultPassword          = "demo"
+	DefaultToken             = "demo-token"
+	DefaultTwoFactorCode     = 123456
+	DefaultDomainID          = "demo-domain"
+	DefaultProjectID         = "demo-project"
+	DefaultRegion            = "sto2"
+	DefaultGardenDomain      = "public"
+	DefaultOperationDuration = 30 * time.Second
+)
+
+// API is an in-memory fake of Cleura API. It implements http.Handler and is safe for concurrent use.
+type API struct {
+	mu                sync.Mutex
+	mux               *http.ServeMux
+	now               func() time.Time
+	operationDuration time.Duration
+	users             map[string]*user
+	tokens            map[string]string
+	verifications     map[string]string
+	domains           []cleura.OpenstackDomain
+	projects          []cleura.OpenstackProject
+	profile           *cleura.CloudProfile // Set by SetCloudProfile, CloudProfileAt server time if nil
+	shoots            map[shootKey]*shoot
+	sequence          int
+}
+
+type user struct {
+	password  string
+	twoFactor bool
+}
+
+// NewAPI returns fake API seeded with a demo user, token, domain, project and cloud profile.
+func NewAPI() *API {
+	api := &API{
+		now:               time.Now,
+		operationDuration: DefaultOperationDuration,
+		users:             map[string]*user{},
+		tokens:            map[string]string{},
+		verifications:     map[string]string{},
+		shoots:            map[shootKey]*shoot{},
+	}
+	api.AddUser(DefaultUsername, DefaultPassword, false)
+	api.AddToken(DefaultUsername, DefaultToken)
+	api.AddDomain(cleura.OpenstackDomain{
+		Id:      DefaultDomainID,
+		Name:    "Demo domain",
+		Status:  "active",
+		Enabled: true,
+		Area: cleura.OpenstackArea{
+			Name: "Europe",
+			Tag:  "eu",
+			Regions: []cleura.OpenstackRegion{
+				{Name: "Stockholm", Region: "sto2", Status: "active"},
+				{Name: "Karlskrona", Region: "kna1", Status: "active"},
+			},
+		},
+	})
+	api.AddProject(cleura.OpenstackProject{
+		Id:          DefaultProjectID,
+		Name:        "demo",
+		DomainId:    DefaultDomainID,
+		Enabled:     true,
+		Default:     true,
+		Description: "Demo project",
+	})
+	api.routes()
+	return api
+}
+
+// AddUser registers user able to log in with password. Users with two-factor
+// authentication enabled confirm login with DefaultTwoFactorCode.
+func (a *API) AddUser(username, password string, twoFactor bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.users[username] = &user{password: password, twoFactor: twoFactor}
+}
+
+// AddToken registers a valid token for the user.
+func (a *API) AddToken(username, token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.tokens[token] = username
+}
+
+// RevokeToken invalidates token, e.g. to simulate token expiration.
+func (a *API) RevokeToken(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.tokens, token)
+}
+
+// AddDomain adds openstack domain.
+func (a *API) AddDomain(domain cleura.OpenstackDomain) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.domains = append(a.domains, domain)
+}
+
+// AddProject adds openstack project to the domain set in project.DomainId.
+func (a *API) AddProject(project cleura.OpenstackProject) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.projects = append(a.projects, project)
+}
+
+// SetCloudProfile replaces cloud profile returned for every gardener domain.
+// By default CloudProfileAt the time of the server clock is returned.
+func (a *API) SetCloudProfile(profile cleura.CloudProfile) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.profile = &profile
+}
+
+// SetOperationDuration sets how long simulated shoot operations take. Zero completes operations immediately.
+func (a *API) SetOperationDuration(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.operationDuration = d
+}
+
+// SetClock replaces time source used to simulate operation progress.
+func (a *API) SetClock(now func() time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.now = now
+}
+
+// AddShoot stores shoot cluster as is, without running any operation.
+func (a *API) AddShoot(gardenDomain, region, project string, shootCluster cleura.ShootClusterResponse) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	key := shootKey{gardenDomain, region, project, shootCluster.Metadata.Name}
+	a.shoots[key] = &shoot{state: shootCluster}
+}
+
+// Shoot returns current state of a shoot cluster.
+func (a *API) Shoot(gardenDomain, region, project, name string) (cleura.ShootClusterResponse, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.lookupShoot(shootKey{gardenDomain, region, project, name})
+	if !ok {
+		return cleura.ShootClusterResponse{}, false
+	}
+	return s.state, true
+}
+
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mux.ServeHTTP(w, r)
+}
+
+// Server is a fake Cleura API listening on a local test server.
+type Server struct {
+	*httptest.Server
+	API *API
+}
+
+// NewServer starts a test server serving api. NewAPI() is used if api is nil.
+func NewServer(api *API) *Server {
+	if api == nil {
+		api = NewAPI()
+	}
+	return &Server{
+		Server: httptest.NewServer(api),
+		API:    api,
+	}
+}
+
+// CleuraClient returns client for the server authenticated with DefaultToken.
+// Options are applied after the defaults.
+func (s *Server) CleuraClient(opts ...cleura.Option) (*cleura.Client, error) {
+	return cleura.New(append([]cleura.Option{
+		cleura.WithHostURL(s.URL),
+		cleura.WithHTTPClient(s.Client()),
+		cleura.WithToken(DefaultUsername, DefaultToken),
+	}, opts...)...)
+}
+
+// Return next unique number for generated names and ids.
+func (a *API) nextSequence() int {
+	a.sequence++
+	return a.sequence
+}
+
+// Return generated id with a given prefix.
+func (a *API) newID(prefix string) string {
+	return fmt.Sprintf("%s%08x", prefix, a.nextSequence())
+}
@@ -0,0 +1,278 @@
+package cleuratest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+// Operation types reported in shoot last operation.
+const (
+	operationCreate    = "Create"
+	operationReconcile = "Reconcile"
+	operationDelete    = "Delete"
+)
+
+// Condition types reported for shoot clusters.
+var conditionTypes = []string{
+	"APIServerAvailable",
+	"ControlPlaneHealthy",
+	"ObservabilityComponentsHealthy",
+	"EveryNodeReady",
+	"SystemComponentsHealthy",
+}
+
+type shootKey struct {
+	gardenDomain string
+	region       string
+	project      string
+	name         string
+}
+
+type shoot struct {
+	state cleura.ShootClusterResponse
+	op    *operation
+}
+
+// Simulated asynchronous shoot operation.
+type operation struct {
+	opType  string
+	started time.Time
+	// Applied to the shoot state when operation completes.
+	finish func(s *shoot)
+	// Shoot is removed when operation completes.
+	deletes bool
+}
+
+// Return shoot with up to date operation progress. Shoots deleted in the meantime are removed.
+func (a *API) lookupShoot(key shootKey) (*shoot, bool) {
+	s, ok := a.shoots[key]
+	if !ok {
+		return nil, false
+	}
+	if a.progress(s) {
+		delete(a.shoots, key)
+		return nil, false
+	}
+	return s, true
+}
+
+// Advance simulated operation of the shoot. Returns true if shoot is deleted.
+func (a *API) progress(s *shoot) bool {
+	if s.op == nil {
+		return false
+	}
+	elapsed := a.now().Sub(s.op.started)
+	lastOp := &s.state.Status.LastOperation
+	if elapsed < a.operationDuration {
+		lastOp.State = cleura.OperationStateProcessing
+		lastOp.Progress = int16(max(1, elapsed*100/a.operationDuration))
+		return false
+	}
+	if s.op.deletes {
+		return true
+	}
+	if s.op.finish != nil {
+		s.op.finish(s)
+	}
+	lastOp.State = cleura.OperationStateSucceeded
+	lastOp.Progress = 100
+	setConditions(s, conditionStatus(s.state.Status.Hibernated))
+	s.op = nil
+	return false
+}
+
+// Start simulated operation on the shoot.
+func (a *API) startOperation(s *shoot, op *operation) {
+	op.started = a.now()
+	s.op = op
+	s.state.Status.LastOperation = cleura.LastOperationDetails{
+		Type:     op.opType,
+		State:    cleura.OperationStateProcessing,
+		Progress: 0,
+	}
+	if op.opType == operationCreate {
+		setConditions(s, "Unknown")
+	}
+	a.progress(s)
+}
+
+// Check if shoot is busy with an unfinished operation.
+func (s *shoot) busy() bool {
+	return s.op != nil
+}
+
+func conditionStatus(hibernated bool) string {
+	if hibernated {
+		return "False"
+	}
+	return "True"
+}
+
+func setConditions(s *shoot, status string) {
+	conditions := make([]cleura.Condition, 0, len(conditionTypes))
+	for _, conditionType := range conditionTypes {
+		message := ""
+		if status != "True" {
+			message = fmt.Sprintf("%s is not ready", conditionType)
+		}
+		conditions = append(conditions, cleura.Condition{Type: conditionType, Status: status, Message: message})
+	}
+	s.state.Status.Conditions = conditions
+}
+
+// Convert shoot create request into a stored shoot state.
+func (a *API) newShootState(region string, req cleura.ShootClusterRequestConfig) cleura.ShootClusterResponse {
+	state := cleura.ShootClusterResponse{
+		Metadata: cleura.MetadataFieldsResponse{
+			Name: req.Name,
+			UID:  a.newID("uid-"),
+		},
+		Spec: cleura.SpecFieldsResponse{
+			Purpose: "evaluation",
+			Region:  region,
+			Provider: cleura.ProviderDetailsUpdateResponse{
+				InfrastructureConfig: cleura.InfrastructureConfigDetails{
+					FloatingPoolName: "ext-net",
+				},
+				Workers: []cleura.WorkerUpdateResponse{},
+			},
+			Maintenance: cleura.MaintenanceDetails{
+				AutoUpdate: &cleura.AutoUpdateDetails{KubernetesVersion: true, MachineImageVersion: true},
+				TimeWindow: &cleura.TimeWindowDetails{Begin: "000000+0000", End: "010000+0000"},
+			},
+		},
+		Status: cleura.StatusFieldsResponse{
+			AdvertisedAddresses: []cleura.AdvertisedAddress{
+				{Name: "external", Url: fmt.Sprintf("https://api.%s.%s.fake.cleura.test", req.Name, region)},
+			},
+		},
+	}
+	if req.Provider != nil {
+		state.Spec.Provider.InfrastructureConfig = req.Provider.InfrastructureConfig
+		if state.Spec.Provider.InfrastructureConfig.FloatingPoolName == "" {
+			state.Spec.Provider.InfrastructureConfig.FloatingPoolName = "ext-net"
+		}
+		for _, worker := range req.Provider.Workers {
+			state.Spec.Provider.Workers = append(state.Spec.Provider.Workers, a.newWorker(worker))
+		}
+	}
+	if req.EnableHaControlPlane {
+		state.Spec.ControlPlane.HighAvailability.FailureTolerance.Type = cleura.FailureToleranceNode
+	}
+	a.applyShootUpdate(&state, req)
+	return state
+}
+
+// Apply fields set in shoot request to the shoot state.
+func (a *API) applyShootUpdate(state *cleura.ShootClusterResponse, req cleura.ShootClusterRequestConfig) {
+	if req.Purpose != "" {
+		state.Spec.Purpose = req.Purpose
+	}
+	if req.KubernetesVersion != nil {
+		state.Spec.Kubernetes.Version = req.KubernetesVersion.Version
+	}
+	if req.Hibernation != nil {
+		schedules := make([]cleura.HibernationResponseSchedule, 0, len(req.Hibernation.HibernationSchedules))
+		for _, schedule := range req.Hibernation.HibernationSchedules {
+			schedules = append(schedules, cleura.HibernationResponseSchedule{Start: schedule.Start, End: schedule.End, Location: "Etc/UTC"})
+		}
+		state.Spec.Hibernation.HibernationResponseSchedules = schedules
+	}
+	if req.Maintenance != nil {
+		if req.Maintenance.AutoUpdate != nil {
+			autoUpdate := *req.Maintenance.AutoUpdate
+			state.Spec.Maintenance.AutoUpdate = &autoUpdate
+		}
+		if req.Maintenance.TimeWindow != nil {
+			timeWindow := *req.Maintenance.TimeWindow
+			state.Spec.Maintenance.TimeWindow = &timeWindow
+		}
+	}
+}
+
+// Convert worker request into stored worker state, generating name if not set.
+func (a *API) newWorker(req cleura.WorkerRequest) cleura.WorkerUpdateResponse {
+	name := req.Name
+	if name == "" {
+		name = fmt.Sprintf("wg%04d", a.nextSequence()%10000)
+	}
+	worker := cleura.WorkerUpdateResponse{
+		Name:        name,
+		Minimum:     req.Minimum,
+		Maximum:     req.Maximum,
+		MaxSurge:    req.MaxSurge,
+		Machine:     req.Machine,
+		Volume:      req.Volume,
+		Labels:      keyValueMap(req.Labels),
+		Annotations: keyValueMap(req.Annotations),
+		Taints:      req.Taints,
+		Zones:       req.Zones,
+	}
+	if worker.MaxSurge == 0 {
+		worker.MaxSurge = 1
+	}
+	if len(worker.Zones) == 0 {
+		worker.Zones = []string{"nova"}
+	}
+	if worker.Taints == nil {
+		worker.Taints = []cleura.Taint{}
+	}
+	return worker
+}
+
+func keyValueMap(pairs []cleura.KeyValuePair) map[string]string {
+	out := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		out[pair.Key] = pair.Value
+	}
+	return out
+}
+
+// Convert stored shoot state to create response.
+func createResponse(state cleura.ShootClusterResponse) cleura.ShootClusterCreateResponse {
+	workers := make([]cleura.WorkerCreateResponse, 0, len(state.Spec.Provider.Workers))
+	for _, worker := range state.Spec.Provider.Workers {
+		workers = append(workers, cleura.WorkerCreateResponse{
+			Name:        worker.Name,
+			Minimum:     worker.Minimum,
+			Maximum:     worker.Maximum,
+			MaxSurge:    worker.MaxSurge,
+			Machine:     worker.Machine,
+			Volume:      worker.Volume,
+			Labels:      keyValuePairMap(worker.Labels),
+			Annotations: keyValuePairMap(worker.Annotations),
+			Taints:      worker.Taints,
+			Zones:       worker.Zones,
+		})
+	}
+	hibernationSchedules := state.Spec.Hibernation.HibernationResponseSchedules
+	return cleura.ShootClusterCreateResponse{
+		Shoot: cleura.ShootClusterCreateConfigResponse{
+			Name:       state.Metadata.Name,
+			UID:        state.Metadata.UID,
+			Kubernetes: state.Spec.Kubernetes,
+			Provider: cleura.ProviderDetailsCreateResponse{
+				InfrastructureConfig: state.Spec.Provider.InfrastructureConfig,
+				Workers:              workers,
+			},
+			Purpose: state.Spec.Purpose,
+			Region:  state.Spec.Region,
+			Hibernation: cleura.HibernationDetails{
+				Enabled:                      len(hibernationSchedules) > 0,
+				HibernationResponseSchedules: hibernationSchedules,
+			},
+			Maintenance:  state.Spec.Maintenance,
+			ControlPlane: state.Spec.ControlPlane,
+		},
+	}
+}
+
+func keyValuePairMap(values map[string]string) map[string]cleura.KeyValuePair {
+	out := make(map[string]cleura.KeyValuePair, len(values))
+	for key, value := range values {
+		out[key] = cleura.KeyValuePair{Key: key, Value: value}
+	}
+	return out
+}
@@ -1,33 +1,52 @@
 package cleura
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 )
 
 // HostURL - Default Cleura API Endpoint prefix.
 const HostURL string = "https://rest.cleura.cloud"
 
-// Need to know status code for retry.
-type RequestAPIError struct {
-	StatusCode int
-	Err        error
-}
-
-func (r *RequestAPIError) Error() string {
-	return r.Err.Error()
-}
-
-// Client.
+// Client. Safe for concurrent use once configured.
 type Client struct {
 	HostURL    string
 	HTTPClient *http.Client
-	Token      string
-	Auth       AuthStruct
+	// Deprecated: Use AccessToken and SetToken. Token is kept in sync with the token used for
+	// requests, assigning it has the same effect as SetToken. Unlike SetToken, assigning it is
+	// not safe while requests are in flight.
+	Token string
+	Auth  AuthStruct
+	// Retry policy for failed requests. No retries if nil.
+	Retry     *RetryPolicy
+	UserAgent string
+	logger    *slog.Logger
+	limiter   *RateLimiter
+
+	// Guards token and tokenSource.
+	mu          sync.Mutex
+	token       string
+	tokenSource TokenSource
 }
 
+// Request authentication modes.
+type authMode int
+
+const (
+	// Send no token, used for login requests.
+	authNone authMode = iota
+	// Send current token.
+	authToken
+	// Send current token, re-login and retry once if it is rejected.
+	authRefresh
+)
+
 // AuthStruct Wrapper.
 type AuthStructWrapper struct {
 	Auth AuthStruct `json:"auth"`
@@ -71,19 +90,20 @@ type AuthVerifyTwoFactorDetails struct {
 	Code         int    `json:"code"`
 }
 
-// NewClient.
-func NewClient(host, username, password *string, twoFactorAuthEnabled bool) (*Client, error) {
-	c := Client{
-		HTTPClient: &http.Client{Timeout: 600 * time.Second},
-		// Default API URL
-		HostURL: HostURL,
-	}
+// NewClient. Requests issued during construction (token or 2FA code request) are bound to ctx.
+// Thin wrapper around New, kept for compatibility. Additional options are applied after host.
+func NewClient(ctx context.Context, host, username, password *string, twoFactorAuthEnabled bool, options ...Option) (*Client, error) {
+	var opts []Option
 	if host != nil {
-		c.HostURL = *host
+		opts = append(opts, WithHostURL(*host))
+	}
+	c, err := New(append(opts, options...)...)
+	if err != nil {
+		return nil, err
 	}
 	// If username or password not provided, return empty client
 	if username == nil || password == nil {
-		return &c, nil
+		return c, nil
 	}
 	c.Auth = AuthStruct{
 		Username: *username,
@@ -92,46 +112,184 @@ func NewClient(host, username, password *string, twoFactorAuthEnabled bool) (*Cl
 	// Return client without token if two factor is enabled
 	if twoFactorAuthEnabled {
 		c.Auth.TwoFactorMethod = "sms"
-		err := c.Request2FactorCode()
+		err := c.Request2FactorCode(ctx)
 		if err != nil {
 			return nil, err
 		}
-		return &c, nil
+		return c, nil
 	}
-	ar, err := c.GetToken()
+	ar, err := c.GetToken(ctx)
 	if err != nil {
 		return nil, err
 	}
-	c.Token = ar.Token
-	return &c, nil
+	c.SetToken(ar.Token)
+	return c, nil
 }
 
+// NewClientNoPassword. Thin wrapper around New, kept for compatibility.
 func NewClientNoPassword(host, username, token *string) (*Client, error) {
-	c := Client{
-		HTTPClient: &http.Client{Timeout: 600 * time.Second},
-		// Default API URL
-		HostURL: HostURL,
-	}
+	var opts []Option
 	if host != nil {
-		c.HostURL = *host
+		opts = append(opts, WithHostURL(*host))
 	}
-	// If username or password not provided, return empty client
-	if username == nil || token == nil {
-		return &c, nil
+	// If username or token not provided, return empty client
+	if username != nil && token != nil {
+		opts = append(opts, WithToken(*username, *token))
 	}
-	c.Auth = AuthStruct{
-		Username: *username,
+	return New(opts...)
+}
+
+// Return token used to authenticate requests, obtaining one from token source if needed.
+func (c *Client) AccessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.adoptAssignedToken()
+	if c.token != "" || c.tokenSource == nil {
+		return c.token, nil
 	}
-	c.Token = *token
-	return &c, nil
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.token, c.Token = token, token
+	return token, nil
+}
+
+// Replace token source with a static token.
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token, c.Token = token, token
+	c.tokenSource = StaticTokenSource(token)
+}
+
+// Use token assigned to the deprecated Token field, if it has been changed since last use.
+// Must be called with mu held.
+func (c *Client) adoptAssignedToken() {
+	if c.Token == c.token {
+		return
+	}
+	c.token = c.Token
+	if c.Token != "" {
+		c.tokenSource = StaticTokenSource(c.Token)
+	}
+}
+
+// Obtain new token after `rejected` token has been refused by the API.
+// Returns false if token source can not refresh.
+func (c *Client) refreshToken(ctx context.Context, rejected string) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.adoptAssignedToken()
+	// Already refreshed by a concurrent request
+	if c.token != rejected {
+		return c.token, true, nil
+	}
+	source, ok := c.tokenSource.(RefreshableTokenSource)
+	if !ok {
+		return "", false, nil
+	}
+	token, err := source.Refresh(ctx)
+	if err != nil {
+		return "", true, err
+	}
+	c.token, c.Token = token, token
+	return token, true, nil
 }
 
 func (c *Client) doRequest(req *http.Request, successResponse int) ([]byte, error) {
-	token := c.Token
+	return c.doRequestAuth(req, successResponse, authRefresh)
+}
+
+func (c *Client) doRequestAuth(req *http.Request, successResponse int, mode authMode) ([]byte, error) {
+	var token string
+	var err error
+	if mode != authNone {
+		token, err = c.AccessToken(req.Context())
+		if err != nil {
+			return nil, err
+		}
+	}
+	body, err := c.doRequestRetry(req, successResponse, token)
+	if mode != authRefresh || !errors.Is(err, ErrUnauthorized) {
+		return body, err
+	}
+	newToken, ok, refreshErr := c.refreshToken(req.Context(), token)
+	if !ok {
+		return nil, err
+	}
+	if refreshErr != nil {
+		return nil, fmt.Errorf("re-authentication failed: %w (original error: %w)", refreshErr, err)
+	}
+	c.log().Debug("token rejected, retrying with a new token", "method", req.Method, "path", req.URL.Path)
+	if err := rewindBody(req); err != nil {
+		return nil, err
+	}
+	return c.doRequestRetry(req, successResponse, newToken)
+}
+
+func (c *Client) doRequestRetry(req *http.Request, successResponse int, token string) ([]byte, error) {
+	attempts := c.Retry.attempts(req.Method)
+	for attempt := 1; ; attempt++ {
+		body, err := c.doRequestOnce(req, successResponse, token)
+		if err == nil || attempt >= attempts || !c.Retry.retryable(err) || req.Context().Err() != nil {
+			return body, err
+		}
+		var retryAfter time.Duration
+		var re *RequestAPIError
+		if errors.As(err, &re) {
+			retryAfter = re.RetryAfter
+		}
+		wait := c.Retry.backoff(attempt, retryAfter)
+		c.log().Debug("retrying request", "method", req.Method, "path", req.URL.Path, "attempt", attempt, "wait", wait, "error", err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Rewind request body before the request is sent again.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+func (c *Client) doRequestOnce(req *http.Request, successResponse int, token string) ([]byte, error) {
 	req.Header.Set("X-AUTH-LOGIN", c.Auth.Username)
 	req.Header.Set("X-AUTH-TOKEN", token)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	logger := c.log()
+	debug := logger.Enabled(req.Context(), slog.LevelDebug)
+	if debug {
+		logger.Debug("api request", "method", req.Method, "url", req.URL.String(), "body", redactedRequestBody(req))
+	}
+	start := time.Now()
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
+		if debug {
+			logger.Debug("api request failed", "method", req.Method, "url", req.URL.String(), "latency", time.Since(start), "error", err)
+		}
 		return nil, err
 	}
 	defer res.Body.Close()
@@ -139,12 +297,19 @@ func (c *Client) doRequest(req *http.Request, successResponse int) ([]byte, erro
 	if err != nil {
 		return nil, err
 	}
+	if debug {
+		logger.Debug("api response", "method", req.Method, "url", req.URL.String(), "status", res.StatusCode, "latency", time.Since(start), "body", redactedResponseBody(req, body))
+	}
 	if res.StatusCode != successResponse {
-		rErr := RequestAPIError{
-			Err:        fmt.Errorf("actual_status: %d, expected_status: %d, body: %s", res.StatusCode, successResponse, body),
-			StatusCode: res.StatusCode,
-		}
-		return nil, &rErr
+		return nil, newRequestAPIError(req, res, body, successResponse)
 	}
 	return body, nil
 }
+
+// Return client logger. Clients built without New log nothing.
+func (c *Client) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return c.logger
+}
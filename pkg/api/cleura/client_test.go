@@ -0,0 +1,44 @@
+package cleura_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+func TestClientDeprecatedTokenField(t *testing.T) {
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	ctx := context.Background()
+
+	// Clients built as struct literals, as before New existed
+	literal := &cleura.Client{HostURL: srv.URL, HTTPClient: srv.Client(), Auth: cleura.AuthStruct{Username: cleuratest.DefaultUsername}, Token: cleuratest.DefaultToken}
+	if _, err := literal.ListDomains(ctx); err != nil {
+		t.Fatalf("struct literal client: %v", err)
+	}
+
+	client, err := srv.CleuraClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListDomains(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if client.Token != cleuratest.DefaultToken {
+		t.Errorf("Token = %q after request, want %q", client.Token, cleuratest.DefaultToken)
+	}
+	client.Token = "revoked"
+	if _, err := client.ListDomains(ctx); !errors.Is(err, cleura.ErrUnauthorized) {
+		t.Errorf("error with assigned invalid token = %v, want %v", err, cleura.ErrUnauthorized)
+	}
+	client.SetToken(cleuratest.DefaultToken)
+	if client.Token != cleuratest.DefaultToken {
+		t.Errorf("Token = %q after SetToken, want %q", client.Token, cleuratest.DefaultToken)
+	}
+	if _, err := client.ListDomains(ctx); err != nil {
+		t.Errorf("error after SetToken: %v", err)
+	}
+}
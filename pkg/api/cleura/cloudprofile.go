@@ -1,16 +1,24 @@
 package cleura
 
 import (
+	"cmp"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Get Cloud Profile Data.
 // This can be used get available kubernetes versions,machine types and images suitable for
 // specification in shoot clusters/ worker groups.
-func (c *Client) GetCloudProfile(gardenDomain string) (*CloudProfile, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/gardener/v1/%s/cloudprofile", c.HostURL, gardenDomain), nil)
+func (c *Client) GetCloudProfile(ctx context.Context, gardenDomain string) (*CloudProfile, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/gardener/v1/%s/cloudprofile", c.HostURL, gardenDomain), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -31,3 +39,385 @@ func (c *Client) GetCloudProfile(gardenDomain string) (*CloudProfile, error) {
 
 	return &cProfile, nil
 }
+
+// Cloud profile version classifications.
+const (
+	ClassificationPreview    = "preview"
+	ClassificationSupported  = "supported"
+	ClassificationDeprecated = "deprecated"
+)
+
+// ErrNotInCloudProfile is returned when a version, machine type, image or region is not offered by the cloud profile.
+var ErrNotInCloudProfile = errors.New("not found in cloud profile")
+
+// Expired reports whether version has passed its expiration date at `now`. Versions without
+// expiration date or with a malformed one never expire.
+func (v CPVersion) Expired(now time.Time) bool {
+	if v.ExpirationDate == "" {
+		return false
+	}
+	expires, err := time.Parse(time.RFC3339, v.ExpirationDate)
+	if err != nil {
+		return false
+	}
+	return !now.Before(expires)
+}
+
+// Usable reports whether version can be used for new clusters and worker groups at `now`,
+// i.e. it is not a preview and has not expired.
+func (v CPVersion) Usable(now time.Time) bool {
+	return v.Classification != ClassificationPreview && !v.Expired(now)
+}
+
+// LatestVersion returns the highest version not expired at `now` with one of the given
+// classifications, or with any classification if none are given.
+func LatestVersion(versions []CPVersion, now time.Time, classifications ...string) (CPVersion, bool) {
+	var latest CPVersion
+	found := false
+	for _, v := range versions {
+		if v.Expired(now) || (len(classifications) > 0 && !slices.Contains(classifications, v.Classification)) {
+			continue
+		}
+		if !found || CompareVersions(v.Version, latest.Version) > 0 {
+			latest = v
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// CompareVersions compares dot separated numeric versions (ex: "1.31.4", "1592.4.0") and returns
+// -1, 0 or +1. Missing components count as zero and a leading "v" is ignored. Non-numeric
+// components are compared as strings.
+func CompareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := range max(len(as), len(bs)) {
+		ap, bp := "0", "0"
+		if i < len(as) {
+			ap = as[i]
+		}
+		if i < len(bs) {
+			bp = bs[i]
+		}
+		an, aErr := strconv.Atoi(ap)
+		bn, bErr := strconv.Atoi(bp)
+		var c int
+		if aErr == nil && bErr == nil {
+			c = cmp.Compare(an, bn)
+		} else {
+			c = cmp.Compare(ap, bp)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Split version into numeric major and minor components.
+func majorMinor(version string) (int, int, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid version %q, expected <major>.<minor>[.<patch>]", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	return major, minor, nil
+}
+
+// KubernetesVersion returns Kubernetes version entry with exact `version`.
+func (s CloudProfileSpec) KubernetesVersion(version string) (CPVersion, error) {
+	for _, v := range s.Kubernetes.Versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return CPVersion{}, fmt.Errorf("kubernetes version %s: %w", version, ErrNotInCloudProfile)
+}
+
+// LatestSupportedKubernetesVersion returns the highest Kubernetes version classified as supported
+// that has not expired.
+func (s CloudProfileSpec) LatestSupportedKubernetesVersion() (CPVersion, error) {
+	v, ok := LatestVersion(s.Kubernetes.Versions, time.Now(), ClassificationSupported)
+	if !ok {
+		return CPVersion{}, fmt.Errorf("supported kubernetes version: %w", ErrNotInCloudProfile)
+	}
+	return v, nil
+}
+
+// UpgradableKubernetesVersions returns non-expired versions a cluster running `from` may be
+// upgraded to: higher patch versions of the same minor and versions of the next minor.
+// Minor versions can not be skipped. Result is sorted in ascending order.
+func (s CloudProfileSpec) UpgradableKubernetesVersions(from string) ([]CPVersion, error) {
+	fromMajor, fromMinor, err := majorMinor(from)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var upgrades []CPVersion
+	for _, v := range s.Kubernetes.Versions {
+		major, minor, err := majorMinor(v.Version)
+		if err != nil || v.Expired(now) || CompareVersions(v.Version, from) <= 0 {
+			continue
+		}
+		if major == fromMajor && (minor == fromMinor || minor == fromMinor+1) {
+			upgrades = append(upgrades, v)
+		}
+	}
+	slices.SortFunc(upgrades, func(a, b CPVersion) int {
+		return CompareVersions(a.Version, b.Version)
+	})
+	return upgrades, nil
+}
+
+// MachineTypeInfo is a machine type with parsed resource quantities.
+type MachineTypeInfo struct {
+	Name         string
+	Architecture string
+	Usable       bool
+	// Number of CPU cores, fractional for millicore quantities.
+	CPU float64
+	// Memory in bytes.
+	Memory int64
+	GPU    int
+}
+
+// MemoryGiB returns memory in gibibytes.
+func (m MachineTypeInfo) MemoryGiB() float64 {
+	return float64(m.Memory) / (1 << 30)
+}
+
+// Parse resource quantities of the machine type.
+func (m CPMachineType) Parse() (MachineTypeInfo, error) {
+	info := MachineTypeInfo{Name: m.Name, Architecture: m.Architecture, Usable: m.Usable}
+	cpu, err := parseQuantity(m.Cpu)
+	if err != nil {
+		return info, fmt.Errorf("machine type %s: cpu: %w", m.Name, err)
+	}
+	memory, err := parseQuantity(m.Memory)
+	if err != nil {
+		return info, fmt.Errorf("machine type %s: memory: %w", m.Name, err)
+	}
+	gpu, err := parseQuantity(m.Gpu)
+	if err != nil {
+		return info, fmt.Errorf("machine type %s: gpu: %w", m.Name, err)
+	}
+	info.CPU = cpu
+	info.Memory = int64(memory)
+	info.GPU = int(gpu)
+	return info, nil
+}
+
+// MachineTypeFilter selects machine types. Zero value matches every machine type.
+type MachineTypeFilter struct {
+	// Only include machine types of given architecture, ex: "amd64".
+	Architecture string
+	// Only include usable machine types.
+	UsableOnly bool
+	// Only include machine types with at least given resources. Memory is in bytes.
+	MinCPU    float64
+	MinMemory int64
+	MinGPU    int
+}
+
+func (f MachineTypeFilter) match(m MachineTypeInfo) bool {
+	return (f.Architecture == "" || f.Architecture == m.Architecture) &&
+		(!f.UsableOnly || m.Usable) &&
+		m.CPU >= f.MinCPU &&
+		m.Memory >= f.MinMemory &&
+		m.GPU >= f.MinGPU
+}
+
+// MachineType returns machine type `name` with parsed resource quantities.
+func (s CloudProfileSpec) MachineType(name string) (MachineTypeInfo, error) {
+	for _, m := range s.MachineTypes {
+		if m.Name == name {
+			return m.Parse()
+		}
+	}
+	return MachineTypeInfo{}, fmt.Errorf("machine type %s: %w", name, ErrNotInCloudProfile)
+}
+
+// FindMachineTypes returns machine types matching filter in cloud profile order.
+func (s CloudProfileSpec) FindMachineTypes(filter MachineTypeFilter) ([]MachineTypeInfo, error) {
+	var types []MachineTypeInfo
+	for _, m := range s.MachineTypes {
+		info, err := m.Parse()
+		if err != nil {
+			return nil, err
+		}
+		if filter.match(info) {
+			types = append(types, info)
+		}
+	}
+	return types, nil
+}
+
+// MachineImage returns machine image `name`.
+func (s CloudProfileSpec) MachineImage(name string) (CPMachineImage, error) {
+	for _, image := range s.MachineImages {
+		if image.Name == name {
+			return image, nil
+		}
+	}
+	return CPMachineImage{}, fmt.Errorf("machine image %s: %w", name, ErrNotInCloudProfile)
+}
+
+// LatestMachineImageVersion returns the highest usable version of machine image `name`.
+func (s CloudProfileSpec) LatestMachineImageVersion(name string) (CPVersion, error) {
+	image, err := s.MachineImage(name)
+	if err != nil {
+		return CPVersion{}, err
+	}
+	v, ok := LatestVersion(image.Versions, time.Now(), ClassificationSupported, ClassificationDeprecated)
+	if !ok {
+		return CPVersion{}, fmt.Errorf("usable version of machine image %s: %w", name, ErrNotInCloudProfile)
+	}
+	return v, nil
+}
+
+// LatestMachineImageVersions returns the highest usable version of every machine image by image name.
+// Images without usable versions are omitted.
+func (s CloudProfileSpec) LatestMachineImageVersions() map[string]CPVersion {
+	latest := make(map[string]CPVersion, len(s.MachineImages))
+	for _, image := range s.MachineImages {
+		if v, ok := LatestVersion(image.Versions, time.Now(), ClassificationSupported, ClassificationDeprecated); ok {
+			latest[image.Name] = v
+		}
+	}
+	return latest
+}
+
+// Region returns region `name`.
+func (s CloudProfileSpec) Region(name string) (CPRegion, error) {
+	for _, region := range s.Regions {
+		if region.Name == name {
+			return region, nil
+		}
+	}
+	return CPRegion{}, fmt.Errorf("region %s: %w", name, ErrNotInCloudProfile)
+}
+
+// RegionZones returns zones of region `name` along with volume types unavailable in each zone.
+func (s CloudProfileSpec) RegionZones(name string) ([]CPZone, error) {
+	region, err := s.Region(name)
+	if err != nil {
+		return nil, err
+	}
+	return region.Zones, nil
+}
+
+// VolumeTypeAvailable reports whether volumes of `volumeType` can be created in the zone.
+func (z CPZone) VolumeTypeAvailable(volumeType string) bool {
+	return !slices.Contains(z.UnavailableVolumeTypes, volumeType)
+}
+
+// Binary and decimal quantity suffixes, as used by Kubernetes resource quantities.
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12},
+	{"m", 1e-3},
+}
+
+// Parse resource quantity such as "4", "500m" or "16Gi". Empty quantity is zero.
+func parseQuantity(quantity string) (float64, error) {
+	quantity = strings.TrimSpace(quantity)
+	if quantity == "" {
+		return 0, nil
+	}
+	multiplier := 1.0
+	number := quantity
+	for _, s := range quantitySuffixes {
+		if strings.HasSuffix(quantity, s.suffix) {
+			number = strings.TrimSuffix(quantity, s.suffix)
+			multiplier = s.multiplier
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", quantity)
+	}
+	return value * multiplier, nil
+}
+
+// Version queries resolved by ResolveVersion.
+const (
+	// Highest version that has not expired. Unlike other queries it may resolve to a preview version.
+	VersionLatest = "latest"
+	// Highest supported version that has not expired.
+	VersionLatestSupported = "latest-supported"
+)
+
+// Partial version query, resolved into its highest usable patch version.
+var minorVersionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+// IsVersionQuery reports whether version is a query to be resolved by ResolveVersion rather than
+// a full version: VersionLatest, VersionLatestSupported or a partial version "<major>.<minor>" like "1.31".
+func IsVersionQuery(version string) bool {
+	return version == VersionLatest || version == VersionLatestSupported || minorVersionPattern.MatchString(version)
+}
+
+// ResolveVersion resolves version query into a full version from `versions` not expired at `now`. Besides
+// VersionLatest and VersionLatestSupported, query may be a partial version like "<major>.<minor>", resolved into
+// its highest usable (not preview, not expired) patch version. Full versions are returned unchanged.
+func ResolveVersion(versions []CPVersion, query string, now time.Time) (string, error) {
+	if !IsVersionQuery(query) {
+		return query, nil
+	}
+	var latest CPVersion
+	var ok bool
+	switch query {
+	case VersionLatest:
+		latest, ok = LatestVersion(versions, now)
+	case VersionLatestSupported:
+		latest, ok = LatestVersion(versions, now, ClassificationSupported)
+	default:
+		var matching []CPVersion
+		for _, v := range versions {
+			if strings.HasPrefix(v.Version, query+".") {
+				matching = append(matching, v)
+			}
+		}
+		latest, ok = LatestVersion(matching, now, ClassificationSupported, ClassificationDeprecated)
+	}
+	if !ok {
+		return "", fmt.Errorf("version matching %q: %w", query, ErrNotInCloudProfile)
+	}
+	return latest.Version, nil
+}
+
+// ResolveKubernetesVersion resolves Kubernetes version query at `now`, see ResolveVersion.
+func (s CloudProfileSpec) ResolveKubernetesVersion(query string, now time.Time) (string, error) {
+	version, err := ResolveVersion(s.Kubernetes.Versions, query, now)
+	if err != nil {
+		return "", fmt.Errorf("kubernetes %w", err)
+	}
+	return version, nil
+}
+
+// ResolveMachineImageVersion resolves version query for machine image `name` at `now`, see ResolveVersion.
+func (s CloudProfileSpec) ResolveMachineImageVersion(name string, query string, now time.Time) (string, error) {
+	if !IsVersionQuery(query) {
+		return query, nil
+	}
+	image, err := s.MachineImage(name)
+	if err != nil {
+		return "", err
+	}
+	version, err := ResolveVersion(image.Versions, query, now)
+	if err != nil {
+		return "", fmt.Errorf("machine image %s %w", name, err)
+	}
+	return version, nil
+}
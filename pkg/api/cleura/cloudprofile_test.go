@@ -0,0 +1,148 @@
+package cleura_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+func TestIsVersionQuery(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "latest", want: true},
+		{version: "latest-supported", want: true},
+		{version: "1.31", want: true},
+		{version: "1592.4", want: true},
+		{version: "1.31.4", want: false},
+		{version: "", want: false},
+		{version: "1", want: false},
+		{version: "v1.31", want: false},
+		{version: "1.x", want: false},
+		{version: "stable", want: false},
+		{version: "Latest", want: false},
+	}
+	for _, tt := range tests {
+		if got := cleura.IsVersionQuery(tt.version); got != tt.want {
+			t.Errorf("IsVersionQuery(%q) = %t, want %t", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	api := cleuratest.NewAPI()
+	api.SetClock(func() time.Time { return now })
+	srv := cleuratest.NewServer(api)
+	defer srv.Close()
+	client, err := srv.CleuraClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err := client.GetCloudProfile(context.Background(), cleuratest.DefaultGardenDomain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		query   string
+		at      time.Time
+		want    string
+		wantErr error
+	}{
+		{name: "latest includes preview", query: cleura.VersionLatest, at: now, want: "1.32.1"},
+		{name: "latest supported", query: cleura.VersionLatestSupported, at: now, want: "1.31.4"},
+		{name: "minor resolves to highest usable patch", query: "1.31", at: now, want: "1.31.4"},
+		{name: "minor resolved before its patches expired", query: "1.29", at: now.AddDate(0, -6, 0), want: "1.29.12"},
+		{name: "minor with preview only", query: "1.32", at: now, wantErr: cleura.ErrNotInCloudProfile},
+		{name: "minor with expired patches only", query: "1.29", at: now, wantErr: cleura.ErrNotInCloudProfile},
+		{name: "minor not offered", query: "1.40", at: now, wantErr: cleura.ErrNotInCloudProfile},
+		{name: "full version returned unchanged", query: "1.29.12", at: now, want: "1.29.12"},
+		{name: "unknown full version returned unchanged", query: "1.31.9", at: now, want: "1.31.9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := profile.Spec.ResolveKubernetesVersion(tt.query, tt.at)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveKubernetesVersion(%q) = %s, want %s", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveVersionExpiry(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	versions := []cleura.CPVersion{
+		{Version: "1.30.8", Classification: cleura.ClassificationDeprecated, ExpirationDate: now.Add(time.Hour).Format(time.RFC3339)},
+		{Version: "1.30.5", Classification: cleura.ClassificationSupported},
+	}
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{name: "before expiration", at: now, want: "1.30.8"},
+		{name: "at expiration", at: now.Add(time.Hour), want: "1.30.5"},
+		{name: "after expiration", at: now.Add(2 * time.Hour), want: "1.30.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cleura.ResolveVersion(versions, "1.30", tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVersion(1.30) at %s = %s, want %s", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveMachineImageVersion(t *testing.T) {
+	now := time.Now()
+	profile := cleuratest.CloudProfileAt(now)
+	tests := []struct {
+		name    string
+		image   string
+		query   string
+		want    string
+		wantErr error
+	}{
+		{name: "latest supported", image: "gardenlinux", query: cleura.VersionLatestSupported, want: "1592.4.0"},
+		{name: "minor", image: "gardenlinux", query: "1443.10", want: "1443.10.0"},
+		{name: "minor expired", image: "gardenlinux", query: "1312.3", wantErr: cleura.ErrNotInCloudProfile},
+		{name: "unknown image", image: "ubuntu", query: cleura.VersionLatest, wantErr: cleura.ErrNotInCloudProfile},
+		{name: "full version of unknown image returned unchanged", image: "ubuntu", query: "22.04.1", want: "22.04.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := profile.Spec.ResolveMachineImageVersion(tt.image, tt.query, now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveMachineImageVersion(%s, %q) = %s, want %s", tt.image, tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package cleura
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors for classes of API failures. Use with errors.Is:
+//
+//	if errors.Is(err, cleura.ErrNotFound) { ... }
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+)
+
+// RequestAPIError is returned when the API responds with an unexpected status code.
+type RequestAPIError struct {
+	StatusCode int
+	// Sentinel error matching StatusCode, nil if status code is not classified.
+	Err error
+	// Request method and URL path.
+	Method string
+	Path   string
+	// Expected status code for the request.
+	ExpectedStatusCode int
+	// Raw response body and its parsed content, if the body is a Cleura error object.
+	Body    []byte
+	APIBody *APIErrorBody
+	// Delay requested by the server via Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+// APIErrorBody holds known fields of a Cleura API error response.
+type APIErrorBody struct {
+	Code    string
+	Message string
+}
+
+func (r *RequestAPIError) Error() string {
+	detail := string(r.Body)
+	if r.APIBody != nil && r.APIBody.Message != "" {
+		detail = r.APIBody.Message
+		if r.APIBody.Code != "" {
+			detail = fmt.Sprintf("%s (code: %s)", detail, r.APIBody.Code)
+		}
+	}
+	return fmt.Sprintf("%s %s: actual_status: %d, expected_status: %d, body: %s", r.Method, r.Path, r.StatusCode, r.ExpectedStatusCode, detail)
+}
+
+func (r *RequestAPIError) Unwrap() error {
+	return r.Err
+}
+
+// Create RequestAPIError from a response with unexpected status code.
+func newRequestAPIError(req *http.Request, res *http.Response, body []byte, expectedStatus int) *RequestAPIError {
+	return &RequestAPIError{
+		StatusCode:         res.StatusCode,
+		Err:                statusSentinel(res.StatusCode),
+		Method:             req.Method,
+		Path:               req.URL.Path,
+		ExpectedStatusCode: expectedStatus,
+		Body:               body,
+		APIBody:            parseAPIErrorBody(body),
+		RetryAfter:         parseRetryAfter(res.Header.Get("Retry-After")),
+	}
+}
+
+// Map status code to a sentinel error.
+func statusSentinel(statusCode int) error {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusConflict:
+		return ErrConflict
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// Parse error body returned by the API. Returns nil if body is not a json object
+// with a message.
+func parseAPIErrorBody(body []byte) *APIErrorBody {
+	var raw map[string]any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil
+	}
+	// Some endpoints nest the error object under `error`
+	if nested, ok := raw["error"].(map[string]any); ok {
+		raw = nested
+	}
+	var apiBody APIErrorBody
+	for _, key := range []string{"message", "error", "msg"} {
+		if msg, ok := raw[key].(string); ok && msg != "" {
+			apiBody.Message = msg
+			break
+		}
+	}
+	if code, ok := raw["code"]; ok && code != nil {
+		apiBody.Code = fmt.Sprint(code)
+	}
+	if apiBody.Message == "" && apiBody.Code == "" {
+		return nil
+	}
+	return &apiBody
+}
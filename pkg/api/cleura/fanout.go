@@ -0,0 +1,190 @@
+package cleura
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// DefaultGardenDomain - Gardener domain used if none is given.
+const DefaultGardenDomain = "public"
+
+// DefaultConcurrency - Max number of concurrent requests issued by fan-out helpers.
+const DefaultConcurrency = 4
+
+// ShootLocation identifies gardener domain, project and region shoot clusters are listed in.
+type ShootLocation struct {
+	GardenDomain string `json:"gardenDomain"`
+	DomainID     string `json:"domainId"`
+	DomainName   string `json:"domainName"`
+	ProjectID    string `json:"projectId"`
+	ProjectName  string `json:"projectName"`
+	Region       string `json:"region"`
+}
+
+func (l ShootLocation) String() string {
+	return fmt.Sprintf("project %s/region %s", l.ProjectID, l.Region)
+}
+
+// LocatedShootCluster is a shoot cluster tagged with its location.
+type LocatedShootCluster struct {
+	Location ShootLocation        `json:"location"`
+	Shoot    ShootClusterResponse `json:"shoot"`
+}
+
+// LocationError is an error of a request issued for a single location.
+type LocationError struct {
+	Location ShootLocation
+	Err      error
+}
+
+func (e *LocationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Location, e.Err)
+}
+
+func (e *LocationError) Unwrap() error {
+	return e.Err
+}
+
+// FanOutOptions configures helpers enumerating all accessible locations.
+type FanOutOptions struct {
+	// Gardener domain. Defaults to DefaultGardenDomain.
+	GardenDomain string
+	// Max number of concurrent requests. Defaults to DefaultConcurrency.
+	Concurrency int
+	// Only include given domains, projects or regions if set.
+	DomainIDs  []string
+	ProjectIDs []string
+	Regions    []string
+}
+
+// AllShootClusters holds shoot clusters listed in every location and errors of
+// locations that could not be listed.
+type AllShootClusters struct {
+	Shoots []LocatedShootCluster
+	Errors []*LocationError
+}
+
+// ListLocations enumerates enabled domains and projects and regions of their areas
+// and returns every combination matching filters in opts.
+func (c *Client) ListLocations(ctx context.Context, opts FanOutOptions) ([]ShootLocation, error) {
+	opts = opts.withDefaults()
+	domains, err := c.ListDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var locations []ShootLocation
+	for _, domain := range *domains {
+		if !domain.Enabled || !matchFilter(opts.DomainIDs, domain.Id) {
+			continue
+		}
+		projects, err := c.ListProjects(ctx, domain.Id)
+		if err != nil {
+			return nil, fmt.Errorf("listing projects in domain %s: %w", domain.Id, err)
+		}
+		for _, project := range *projects {
+			if !project.Enabled || !matchFilter(opts.ProjectIDs, project.Id) {
+				continue
+			}
+			for _, region := range domain.Area.Regions {
+				if !matchFilter(opts.Regions, region.Region) {
+					continue
+				}
+				locations = append(locations, ShootLocation{
+					GardenDomain: opts.GardenDomain,
+					DomainID:     domain.Id,
+					DomainName:   domain.Name,
+					ProjectID:    project.Id,
+					ProjectName:  project.Name,
+					Region:       region.Region,
+				})
+			}
+		}
+	}
+	return locations, nil
+}
+
+// ListAllShootClusters lists shoot clusters in every location returned by ListLocations,
+// issuing up to opts.Concurrency requests in parallel. Failure to list a single location
+// does not stop listing, errors are collected per location instead.
+func (c *Client) ListAllShootClusters(ctx context.Context, opts FanOutOptions) (*AllShootClusters, error) {
+	opts = opts.withDefaults()
+	locations, err := c.ListLocations(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	var mu sync.Mutex
+	result := &AllShootClusters{}
+	forEachLocation(ctx, locations, opts.Concurrency, func(location ShootLocation) {
+		shoots, err := c.ListShootClusters(ctx, location.GardenDomain, location.Region, location.ProjectID)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.Errors = append(result.Errors, &LocationError{Location: location, Err: err})
+			return
+		}
+		for _, shoot := range shoots {
+			result.Shoots = append(result.Shoots, LocatedShootCluster{Location: location, Shoot: shoot})
+		}
+	})
+	slices.SortFunc(result.Shoots, func(a, b LocatedShootCluster) int {
+		return cmp.Or(
+			compareLocations(a.Location, b.Location),
+			cmp.Compare(a.Shoot.Metadata.Name, b.Shoot.Metadata.Name),
+		)
+	})
+	slices.SortFunc(result.Errors, func(a, b *LocationError) int {
+		return compareLocations(a.Location, b.Location)
+	})
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	return result, nil
+}
+
+// Call fn for every location with up to `concurrency` calls running in parallel.
+// Locations not yet started are skipped once ctx is done.
+func forEachLocation(ctx context.Context, locations []ShootLocation, concurrency int, fn func(ShootLocation)) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, location := range locations {
+		select {
+		case <-ctx.Done():
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(location)
+		}()
+	}
+}
+
+func compareLocations(a, b ShootLocation) int {
+	return cmp.Or(
+		cmp.Compare(a.DomainID, b.DomainID),
+		cmp.Compare(a.ProjectName, b.ProjectName),
+		cmp.Compare(a.ProjectID, b.ProjectID),
+		cmp.Compare(a.Region, b.Region),
+	)
+}
+
+func (o FanOutOptions) withDefaults() FanOutOptions {
+	if o.GardenDomain == "" {
+		o.GardenDomain = DefaultGardenDomain
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+	return o
+}
+
+// Check if value is allowed by a filter. Empty filter allows everything.
+func matchFilter(filter []string, value string) bool {
+	return len(filter) == 0 || slices.Contains(filter, value)
+}
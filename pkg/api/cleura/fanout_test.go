@@ -0,0 +1,210 @@
+package cleura_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+// Transport holding shoot list requests in flight for a while, tracking how many run
+// concurrently, and failing requests for paths in `fail` with a server error.
+type fanOutTransport struct {
+	mu          sync.Mutex
+	next        http.RoundTripper
+	delay       time.Duration
+	fail        map[string]bool
+	inFlight    int
+	maxInFlight int
+}
+
+func (t *fanOutTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !strings.Contains(r.URL.Path, "/shoot/") {
+		return t.next.RoundTrip(r)
+	}
+	t.mu.Lock()
+	t.inFlight++
+	t.maxInFlight = max(t.maxInFlight, t.inFlight)
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.inFlight--
+		t.mu.Unlock()
+	}()
+	time.Sleep(t.delay)
+	if t.fail[r.URL.Path] {
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"code":500,"message":"backend unavailable"}`)),
+			Request:    r,
+		}, nil
+	}
+	return t.next.RoundTrip(r)
+}
+
+func shootListPath(region, project string) string {
+	return "/gardener/v1/" + cleuratest.DefaultGardenDomain + "/shoot/" + region + "/" + project
+}
+
+// Fake API with default project, an "analytics" project sorted before it, a disabled
+// project and a disabled domain, each with regions sto2 and kna1.
+func newFanOutTestServer(t *testing.T, transport *fanOutTransport) (*cleuratest.Server, *cleura.Client) {
+	t.Helper()
+	api := cleuratest.NewAPI()
+	api.AddProject(cleura.OpenstackProject{Id: "analytics-project", Name: "analytics", DomainId: cleuratest.DefaultDomainID, Enabled: true})
+	api.AddProject(cleura.OpenstackProject{Id: "archived-project", Name: "archived", DomainId: cleuratest.DefaultDomainID})
+	api.AddDomain(cleura.OpenstackDomain{
+		Id:   "disabled-domain",
+		Area: cleura.OpenstackArea{Regions: []cleura.OpenstackRegion{{Region: "sto2"}}},
+	})
+	api.AddProject(cleura.OpenstackProject{Id: "hidden-project", DomainId: "disabled-domain", Enabled: true})
+	srv := cleuratest.NewServer(api)
+	t.Cleanup(srv.Close)
+	transport.next = srv.Client().Transport
+	client, err := srv.CleuraClient(cleura.WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+func testLocation(projectID, projectName, region string) cleura.ShootLocation {
+	return cleura.ShootLocation{
+		GardenDomain: cleuratest.DefaultGardenDomain,
+		DomainID:     cleuratest.DefaultDomainID,
+		DomainName:   "Demo domain",
+		ProjectID:    projectID,
+		ProjectName:  projectName,
+		Region:       region,
+	}
+}
+
+func TestListLocations(t *testing.T) {
+	_, client := newFanOutTestServer(t, &fanOutTransport{})
+	demoSto2 := testLocation(cleuratest.DefaultProjectID, "demo", "sto2")
+	demoKna1 := testLocation(cleuratest.DefaultProjectID, "demo", "kna1")
+	analyticsSto2 := testLocation("analytics-project", "analytics", "sto2")
+	analyticsKna1 := testLocation("analytics-project", "analytics", "kna1")
+	tests := []struct {
+		name string
+		opts cleura.FanOutOptions
+		want []cleura.ShootLocation
+	}{
+		{name: "enabled domains and projects", want: []cleura.ShootLocation{demoSto2, demoKna1, analyticsSto2, analyticsKna1}},
+		{name: "project filter", opts: cleura.FanOutOptions{ProjectIDs: []string{"analytics-project", "archived-project"}}, want: []cleura.ShootLocation{analyticsSto2, analyticsKna1}},
+		{name: "region filter", opts: cleura.FanOutOptions{Regions: []string{"kna1"}}, want: []cleura.ShootLocation{demoKna1, analyticsKna1}},
+		{name: "disabled domain", opts: cleura.FanOutOptions{DomainIDs: []string{"disabled-domain"}}},
+		{
+			name: "garden domain",
+			opts: cleura.FanOutOptions{GardenDomain: "private", ProjectIDs: []string{cleuratest.DefaultProjectID}, Regions: []string{"sto2"}},
+			want: func() []cleura.ShootLocation {
+				l := demoSto2
+				l.GardenDomain = "private"
+				return []cleura.ShootLocation{l}
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ListLocations(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("locations = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Shoots are sorted by project name, project, region and name, failed locations are reported
+// without stopping the listing of other locations.
+func TestListAllShootClusters(t *testing.T) {
+	transport := &fanOutTransport{fail: map[string]bool{shootListPath("kna1", cleuratest.DefaultProjectID): true}}
+	srv, client := newFanOutTestServer(t, transport)
+	add := func(region, project, name string) {
+		srv.API.AddShoot(cleuratest.DefaultGardenDomain, region, project, testShoot(name, "Create", cleura.OperationStateSucceeded, 100))
+	}
+	add("sto2", cleuratest.DefaultProjectID, "web")
+	add("sto2", cleuratest.DefaultProjectID, "api")
+	add("kna1", cleuratest.DefaultProjectID, "lost")
+	add("kna1", "analytics-project", "spark")
+	add("sto2", "analytics-project", "warehouse")
+	add("sto2", "archived-project", "old")
+
+	all, err := client.ListAllShootClusters(context.Background(), cleura.FanOutOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, located := range all.Shoots {
+		got = append(got, located.Location.ProjectID+"/"+located.Location.Region+"/"+located.Shoot.Metadata.Name)
+	}
+	want := []string{
+		"analytics-project/kna1/spark",
+		"analytics-project/sto2/warehouse",
+		cleuratest.DefaultProjectID + "/sto2/api",
+		cleuratest.DefaultProjectID + "/sto2/web",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("shoots = %q, want %q", got, want)
+	}
+	if len(all.Errors) != 1 {
+		t.Fatalf("errors = %v, want 1 location error", all.Errors)
+	}
+	if location := all.Errors[0].Location; location != testLocation(cleuratest.DefaultProjectID, "demo", "kna1") {
+		t.Errorf("failed location = %+v, want demo project in kna1", location)
+	}
+	if !errors.Is(all.Errors[0], cleura.ErrServer) {
+		t.Errorf("error = %v, want %v", all.Errors[0], cleura.ErrServer)
+	}
+}
+
+func TestListAllShootClustersConcurrency(t *testing.T) {
+	for _, tt := range []struct {
+		concurrency int
+		want        int
+	}{
+		{concurrency: 0, want: cleura.DefaultConcurrency},
+		{concurrency: 1, want: 1},
+		{concurrency: 3, want: 3},
+	} {
+		transport := &fanOutTransport{delay: 20 * time.Millisecond}
+		srv, client := newFanOutTestServer(t, transport)
+		// 10 locations, more than any concurrency limit tested
+		for _, id := range []string{"c", "d", "e"} {
+			srv.API.AddProject(cleura.OpenstackProject{Id: id, Name: id, DomainId: cleuratest.DefaultDomainID, Enabled: true})
+		}
+		all, err := client.ListAllShootClusters(context.Background(), cleura.FanOutOptions{Concurrency: tt.concurrency})
+		if err != nil || len(all.Errors) != 0 {
+			t.Fatalf("listing = %v, %v", all, err)
+		}
+		if transport.maxInFlight != tt.want {
+			t.Errorf("concurrency %d: %d requests in flight, want %d", tt.concurrency, transport.maxInFlight, tt.want)
+		}
+	}
+}
+
+// Locations not yet listed are skipped once context is done.
+func TestListAllShootClustersCanceled(t *testing.T) {
+	transport := &fanOutTransport{delay: 20 * time.Millisecond}
+	_, client := newFanOutTestServer(t, transport)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	all, err := client.ListAllShootClusters(ctx, cleura.FanOutOptions{Concurrency: 1})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if all == nil {
+		t.Fatal("partial result is nil")
+	}
+}
@@ -0,0 +1,28 @@
+package cleura
+
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+// FindShootClusters searches every location returned by ListLocations for shoot clusters
+// with a name matching `pattern`. Pattern is either an exact name or a glob in path.Match
+// syntax, e.g. "prod-*". Locations that could not be searched are reported in Errors.
+func (c *Client) FindShootClusters(ctx context.Context, pattern string, opts FanOutOptions) (*AllShootClusters, error) {
+	// Reject malformed patterns before issuing any requests
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	all, err := c.ListAllShootClusters(ctx, opts)
+	if all == nil {
+		return nil, err
+	}
+	found := &AllShootClusters{Errors: all.Errors}
+	for _, located := range all.Shoots {
+		if ok, _ := path.Match(pattern, located.Shoot.Metadata.Name); ok {
+			found.Shoots = append(found.Shoots, located)
+		}
+	}
+	return found, err
+}
@@ -0,0 +1,77 @@
+package cleura_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+func TestFindShootClusters(t *testing.T) {
+	transport := &fanOutTransport{fail: map[string]bool{shootListPath("kna1", "analytics-project"): true}}
+	srv, client := newFanOutTestServer(t, transport)
+	for _, shoot := range []struct{ region, project, name string }{
+		{"sto2", cleuratest.DefaultProjectID, "prod-web"},
+		{"kna1", cleuratest.DefaultProjectID, "prod-api"},
+		{"sto2", cleuratest.DefaultProjectID, "staging"},
+		{"sto2", "analytics-project", "prod"},
+	} {
+		srv.API.AddShoot(cleuratest.DefaultGardenDomain, shoot.region, shoot.project, testShoot(shoot.name, "Create", cleura.OperationStateSucceeded, 100))
+	}
+	tests := []struct {
+		name    string
+		pattern string
+		opts    cleura.FanOutOptions
+		want    []string
+	}{
+		{name: "exact name", pattern: "prod", want: []string{"analytics-project/sto2/prod"}},
+		{name: "glob", pattern: "prod-*", want: []string{cleuratest.DefaultProjectID + "/kna1/prod-api", cleuratest.DefaultProjectID + "/sto2/prod-web"}},
+		{name: "character class", pattern: "prod-[aw]??", want: []string{cleuratest.DefaultProjectID + "/kna1/prod-api", cleuratest.DefaultProjectID + "/sto2/prod-web"}},
+		{name: "glob in region", pattern: "prod*", opts: cleura.FanOutOptions{Regions: []string{"sto2"}}, want: []string{"analytics-project/sto2/prod", cleuratest.DefaultProjectID + "/sto2/prod-web"}},
+		{name: "no match", pattern: "dev-*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := client.FindShootClusters(context.Background(), tt.pattern, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, located := range found.Shoots {
+				got = append(got, located.Location.ProjectID+"/"+located.Location.Region+"/"+located.Shoot.Metadata.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("found %q, want %q", got, tt.want)
+			}
+			// Failed location is reported whenever it is searched
+			wantErrors := 1
+			if len(tt.opts.Regions) > 0 {
+				wantErrors = 0
+			}
+			if len(found.Errors) != wantErrors {
+				t.Errorf("errors = %v, want %d location errors", found.Errors, wantErrors)
+			}
+		})
+	}
+}
+
+func TestFindShootClustersInvalidPattern(t *testing.T) {
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	counter := &countingTransport{next: srv.Client().Transport}
+	client, err := srv.CleuraClient(cleura.WithHTTPClient(&http.Client{Transport: counter}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.FindShootClusters(context.Background(), "prod-[", cleura.FanOutOptions{})
+	if err == nil || !strings.Contains(err.Error(), `invalid pattern "prod-["`) {
+		t.Errorf("error = %v, want invalid pattern", err)
+	}
+	if n := counter.count(listDomainsRequest); n != 0 {
+		t.Errorf("sent %d requests, want none", n)
+	}
+}
@@ -76,9 +76,10 @@ type StatusFieldsResponse struct {
 }
 
 type LastOperationDetails struct {
-	Progress int16  `json:"progress"`
-	State    string `json:"state"`
-	Type     string `json:"type"`
+	Progress    int16  `json:"progress"`
+	State       string `json:"state"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
 }
 
 type Condition struct {
@@ -104,6 +105,14 @@ type FailureToleranceDetails struct {
 	Type string `json:"type"`
 }
 
+// Failure tolerance types of highly available control planes reported in ControlPlaneDetails.
+const (
+	// Control plane replicas are spread across nodes of a single zone.
+	FailureToleranceNode = "node"
+	// Control plane replicas are spread across zones of the region.
+	FailureToleranceZone = "zone"
+)
+
 // Shoot cluster request data model.
 type ShootClusterRequest struct {
 	Shoot ShootClusterRequestConfig `json:"shoot"`
@@ -112,6 +121,7 @@ type ShootClusterRequest struct {
 type ShootClusterRequestConfig struct {
 	Name                 string                  `json:"name,omitempty"`
 	EnableHaControlPlane bool                    `json:"enableHaControlPlane,omitempty"`
+	Purpose              string                  `json:"purpose,omitempty"`
 	KubernetesVersion    *K8sVersion             `json:"kubernetes,omitempty"`
 	Provider             *ProviderDetailsRequest `json:"provider,omitempty"`
 	Hibernation          *HibernationSchedules   `json:"hibernation,omitempty"`
@@ -202,6 +212,7 @@ type ImageDetails struct {
 
 type VolumeDetails struct {
 	Size string `json:"size"`
+	Type string `json:"type,omitempty"`
 }
 
 type KeyValuePair struct {
@@ -216,7 +227,8 @@ type Taint struct {
 }
 
 type HibernationSchedules struct {
-	HibernationSchedules []HibernationSchedule `json:"schedules,omitempty"`
+	// Replaces all schedules of the shoot cluster, an empty list removes them
+	HibernationSchedules []HibernationSchedule `json:"schedules"`
 }
 
 type HibernationSchedule struct {
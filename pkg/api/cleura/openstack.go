@@ -1,13 +1,14 @@
 package cleura
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
-func (c *Client) ListDomains() (*[]OpenstackDomain, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/accesscontrol/v1/openstack/domains", c.HostURL), nil)
+func (c *Client) ListDomains(ctx context.Context) (*[]OpenstackDomain, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/accesscontrol/v1/openstack/domains", c.HostURL), nil)
 	//https://rest.cleura.cloud/accesscontrol/v1/openstack/domains
 	if err != nil {
 		return nil, err
@@ -25,8 +26,8 @@ func (c *Client) ListDomains() (*[]OpenstackDomain, error) {
 	return &domains, nil
 }
 
-func (c *Client) ListProjects(domain_id string) (*[]OpenstackProject, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/accesscontrol/v1/openstack/%s/projects", c.HostURL, domain_id), nil)
+func (c *Client) ListProjects(ctx context.Context, domain_id string) (*[]OpenstackProject, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/accesscontrol/v1/openstack/%s/projects", c.HostURL, domain_id), nil)
 	//https://rest.cleura.cloud/accesscontrol/v1/openstack/:domainId/projects
 	if err != nil {
 		return nil, err
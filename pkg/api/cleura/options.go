@@ -0,0 +1,209 @@
+package cleura
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout - Default timeout for a single HTTP request.
+const DefaultTimeout = 600 * time.Second
+
+// DefaultUserAgent - Default User-Agent header value.
+const DefaultUserAgent = "cleura-client-go"
+
+// Option configures a Client created with New.
+type Option func(*clientOptions) error
+
+type clientOptions struct {
+	hostURL     string
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	timeout     time.Duration
+	userAgent   string
+	logger      *slog.Logger
+	retry       *RetryPolicy
+	limiter     *RateLimiter
+	username    string
+	password    string
+	tokenSource TokenSource
+}
+
+// New creates a Client configured by the given options. No requests are issued.
+//
+//	client, err := cleura.New(
+//		cleura.WithToken("user", "token"),
+//		cleura.WithTransport(proxyTransport),
+//		cleura.WithUserAgent("my-tool/1.0"),
+//	)
+func New(opts ...Option) (*Client, error) {
+	o := clientOptions{
+		hostURL:   HostURL,
+		timeout:   DefaultTimeout,
+		userAgent: DefaultUserAgent,
+		retry:     DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return nil, err
+		}
+	}
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout:   o.timeout,
+			Transport: o.transport,
+		}
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	tokenSource := o.tokenSource
+	if o.password != "" {
+		// Login requests share transport settings with the client
+		var err error
+		tokenSource, err = PasswordTokenSource(o.username, o.password,
+			WithHostURL(o.hostURL),
+			WithHTTPClient(httpClient),
+			WithUserAgent(o.userAgent),
+			WithLogger(logger),
+			WithRetryPolicy(o.retry),
+			WithRateLimiter(o.limiter),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Client{
+		HostURL:     o.hostURL,
+		HTTPClient:  httpClient,
+		Auth:        AuthStruct{Username: o.username},
+		Retry:       o.retry,
+		UserAgent:   o.userAgent,
+		logger:      logger,
+		limiter:     o.limiter,
+		tokenSource: tokenSource,
+	}, nil
+}
+
+// WithHostURL sets Cleura API endpoint prefix. Defaults to HostURL.
+func WithHostURL(url string) Option {
+	return func(o *clientOptions) error {
+		if url == "" {
+			return errors.New("host url must not be empty")
+		}
+		o.hostURL = url
+		return nil
+	}
+}
+
+// WithHTTPClient sets HTTP client used for requests. WithTransport and WithTimeout are ignored if set.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) error {
+		if client == nil {
+			return errors.New("http client must not be nil")
+		}
+		o.httpClient = client
+		return nil
+	}
+}
+
+// WithTransport sets round tripper used for requests, e.g. to route through a proxy
+// or trust a custom CA bundle. Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) error {
+		o.transport = transport
+		return nil
+	}
+}
+
+// WithTimeout sets timeout for a single HTTP request. Defaults to DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout < 0 {
+			return errors.New("timeout must not be negative")
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) error {
+		o.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithLogger sets logger used by the client. Logging is disabled by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) error {
+		o.logger = logger
+		return nil
+	}
+}
+
+// WithRetryPolicy sets retry policy for failed requests. Pass nil to disable retries.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.retry = policy
+		return nil
+	}
+}
+
+// WithRateLimit limits client to `rps` requests per second on average with bursts of up to
+// `burst` requests. Each attempt of a retried request counts. Not limited by default.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(o *clientOptions) error {
+		limiter, err := NewRateLimiter(rps, burst)
+		if err != nil {
+			return err
+		}
+		o.limiter = limiter
+		return nil
+	}
+}
+
+// WithRateLimiter sets rate limiter, allowing it to be shared between clients. Pass nil to disable limiting.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) error {
+		o.limiter = limiter
+		return nil
+	}
+}
+
+// WithToken sets username and an already issued API token.
+func WithToken(username, token string) Option {
+	return WithTokenSource(username, StaticTokenSource(token))
+}
+
+// WithTokenSource sets username and a source of API tokens. If the source implements
+// RefreshableTokenSource, rejected tokens are refreshed and the request is retried once.
+func WithTokenSource(username string, source TokenSource) Option {
+	return func(o *clientOptions) error {
+		if source == nil {
+			return errors.New("token source must not be nil")
+		}
+		o.username = username
+		o.password = ""
+		o.tokenSource = source
+		return nil
+	}
+}
+
+// WithCredentials sets username and password. Token is requested on first use and
+// requested again whenever it expires. Two-factor authentication is not supported.
+func WithCredentials(username, password string) Option {
+	return func(o *clientOptions) error {
+		if username == "" || password == "" {
+			return errors.New("define username and password")
+		}
+		o.username = username
+		o.password = password
+		o.tokenSource = nil
+		return nil
+	}
+}
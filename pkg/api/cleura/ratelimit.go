@@ -0,0 +1,66 @@
+package cleura
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting request rate. It is safe for concurrent use
+// and can be shared between clients.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns limiter allowing `rps` requests per second on average with bursts
+// of up to `burst` requests. Burst is set to ceil(rps) if not positive.
+func NewRateLimiter(rps float64, burst int) (*RateLimiter, error) {
+	if rps <= 0 {
+		return nil, errors.New("requests per second must be positive")
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(rps))
+	}
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// Wait blocks until a request is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// Reserve token, going into debt if bucket is empty
+	l.tokens--
+	wait := time.Duration(0)
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// Give reserved token back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
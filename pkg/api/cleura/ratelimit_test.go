@@ -0,0 +1,113 @@
+package cleura_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+// Call Wait n times and return how long it took.
+func waitN(t *testing.T, l *cleura.RateLimiter, n int) time.Duration {
+	t.Helper()
+	start := time.Now()
+	for range n {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return time.Since(start)
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	for _, rps := range []float64{0, -1} {
+		if _, err := cleura.NewRateLimiter(rps, 1); err == nil {
+			t.Errorf("NewRateLimiter(%v) succeeded, want error", rps)
+		}
+	}
+	// Burst defaults to rounded up rate
+	l, err := cleura.NewRateLimiter(2.5, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := waitN(t, l, 3); elapsed > 50*time.Millisecond {
+		t.Errorf("burst of 3 took %s, want no wait", elapsed)
+	}
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	l, err := cleura.NewRateLimiter(10, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := waitN(t, l, 5); elapsed > 50*time.Millisecond {
+		t.Errorf("burst of 5 took %s, want no wait", elapsed)
+	}
+	// Bucket is empty, next request waits for a token to refill at 10 per second
+	if elapsed := waitN(t, l, 1); elapsed < 80*time.Millisecond || elapsed > 300*time.Millisecond {
+		t.Errorf("request after burst took %s, want about 100ms", elapsed)
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	l, err := cleura.NewRateLimiter(50, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitN(t, l, 2)
+	// Sustained rate is limited to 50 per second
+	if elapsed := waitN(t, l, 5); elapsed < 90*time.Millisecond {
+		t.Errorf("5 requests with empty bucket took %s, want at least 100ms", elapsed)
+	}
+	// Bucket refills up to burst while idle
+	time.Sleep(100 * time.Millisecond)
+	if elapsed := waitN(t, l, 2); elapsed > 15*time.Millisecond {
+		t.Errorf("burst after idle period took %s, want no wait", elapsed)
+	}
+	if elapsed := waitN(t, l, 1); elapsed < 10*time.Millisecond {
+		t.Errorf("request beyond refilled burst took %s, want to wait", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l, err := cleura.NewRateLimiter(10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitN(t, l, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 60*time.Millisecond {
+		t.Errorf("canceled wait returned after %s, want when context is done", elapsed)
+	}
+	// Token reserved by the canceled wait is given back, so the next request waits for one token only
+	if elapsed := waitN(t, l, 1); elapsed > 150*time.Millisecond {
+		t.Errorf("request after canceled wait took %s, want at most 100ms", elapsed)
+	}
+}
+
+// Client waits for the limiter before every request.
+func TestClientRateLimit(t *testing.T) {
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	client, err := srv.CleuraClient(cleura.WithRateLimit(20, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for range 3 {
+		if _, err := client.ListDomains(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 requests at 20 per second took %s, want at least 100ms", elapsed)
+	}
+}
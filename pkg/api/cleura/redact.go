@@ -0,0 +1,107 @@
+package cleura
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Max number of body bytes written to debug log.
+const maxLoggedBodySize = 4096
+
+// Body keys holding secrets, redacted wherever they appear.
+var sensitiveKeys = []string{"password", "token", "verification"}
+
+// Body keys holding secrets within a given parent object only.
+var sensitiveNestedKeys = map[string][]string{
+	"verify2fa": {"code"},
+}
+
+// Endpoints whose response body is a secret in its entirety.
+var sensitiveResponsePaths = []string{"/kubeconfig", "/adminkubeconfig", "/monitoring"}
+
+// Return request body with secrets redacted. Body of the request is left intact.
+func redactedRequestBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return redactBody(data)
+}
+
+// Return response body with secrets redacted.
+func redactedResponseBody(req *http.Request, body []byte) string {
+	for _, suffix := range sensitiveResponsePaths {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			return redacted
+		}
+	}
+	return redactBody(body)
+}
+
+// Redact values of sensitive keys in a json document. Non-json content is returned as is.
+func redactBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return truncate(string(data))
+	}
+	out, err := json.Marshal(redactValue(doc, ""))
+	if err != nil {
+		return redacted
+	}
+	return truncate(string(out))
+}
+
+func redactValue(value any, parent string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if isSensitiveKey(key, parent) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(nested, key)
+		}
+	case []any:
+		for i, nested := range v {
+			v[i] = redactValue(nested, parent)
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string, parent string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if key == sensitive {
+			return true
+		}
+	}
+	for _, sensitive := range sensitiveNestedKeys[strings.ToLower(parent)] {
+		if key == sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(s string) string {
+	if len(s) > maxLoggedBodySize {
+		return s[:maxLoggedBodySize] + "...(truncated)"
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package cleura
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "empty", body: "", want: ""},
+		{name: "not json", body: "plain text", want: "plain text"},
+		{name: "login request", body: `{"auth":{"login":"alice","password":"secret"}}`, want: `{"auth":{"login":"alice","password":"[REDACTED]"}}`},
+		{name: "token response", body: `{"result":"login_ok","token":"abc"}`, want: `{"result":"login_ok","token":"[REDACTED]"}`},
+		{name: "keys are case insensitive", body: `{"Token":"abc"}`, want: `{"Token":"[REDACTED]"}`},
+		{name: "nested in lists", body: `[{"password":"a"},{"name":"b"}]`, want: `[{"password":"[REDACTED]"},{"name":"b"}]`},
+		{name: "2fa code", body: `{"verify2fa":{"code":123456,"verification":"v"}}`, want: `{"verify2fa":{"code":"[REDACTED]","verification":"[REDACTED]"}}`},
+		{name: "code outside 2fa kept", body: `{"code":404,"message":"not found"}`, want: `{"code":404,"message":"not found"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactBody(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactBodyTruncates(t *testing.T) {
+	got := redactBody([]byte(strings.Repeat("x", maxLoggedBodySize+10)))
+	if want := strings.Repeat("x", maxLoggedBodySize) + "...(truncated)"; got != want {
+		t.Errorf("redactBody of long body has length %d, want %d", len(got), len(want))
+	}
+}
+
+func TestRedactedResponseBody(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/gardener/v1/public/shoot/sto2/p/demo/kubeconfig", want: redacted},
+		{path: "/gardener/v1/public/shoot/sto2/p/demo/adminkubeconfig", want: redacted},
+		{path: "/gardener/v1/public/shoot/sto2/p/demo/monitoring", want: redacted},
+		{path: "/gardener/v1/public/shoot/sto2/p/demo", want: `{"name":"demo"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if got := redactedResponseBody(req, []byte(`{"name":"demo"}`)); got != tt.want {
+				t.Errorf("redactedResponseBody = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactedRequestBodyKeepsBody(t *testing.T) {
+	req, err := http.NewRequest("POST", "/auth/v1/tokens", strings.NewReader(`{"auth":{"password":"secret"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := redactedRequestBody(req), `{"auth":{"password":"[REDACTED]"}}`; got != want {
+		t.Errorf("redactedRequestBody = %s, want %s", got, want)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"secret"`) {
+		t.Errorf("request body was modified: %s", body)
+	}
+}
@@ -0,0 +1,57 @@
+package cleura_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+func TestClientDebugLogRedactsSecrets(t *testing.T) {
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	srv.API.AddUser("alice", "s3cret-password", false)
+	srv.API.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, cleura.ShootClusterResponse{
+		Metadata: cleura.MetadataFieldsResponse{Name: "demo"},
+	})
+	var log bytes.Buffer
+	client, err := cleura.New(
+		cleura.WithHostURL(srv.URL),
+		cleura.WithHTTPClient(srv.Client()),
+		cleura.WithCredentials("alice", "s3cret-password"),
+		cleura.WithLogger(slog.New(slog.NewTextHandler(&log, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := client.ListDomains(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetKubeConfig(ctx, cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, "demo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetMonitoringCredentials(ctx, cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, "demo"); err != nil {
+		t.Fatal(err)
+	}
+	token, err := client.AccessToken(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged := log.String()
+	for _, secret := range []string{"s3cret-password", token, "fake-user-token", "fake-monitoring-password"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("debug log contains secret %q:\n%s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, "[REDACTED]") {
+		t.Errorf("debug log has no redacted values:\n%s", logged)
+	}
+	if !strings.Contains(logged, "demo-domain") {
+		t.Errorf("debug log is missing response bodies:\n%s", logged)
+	}
+}
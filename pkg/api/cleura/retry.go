@@ -0,0 +1,108 @@
+package cleura
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how failed requests are retried by the client.
+// Idempotent requests (GET, PUT, DELETE) are retried on 429, 502, 503, 504
+// and transport errors. POST requests are retried only if RetryPost is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every next attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts, including waits requested via Retry-After.
+	MaxBackoff time.Duration
+	// RetryPost enables retries for non-idempotent POST requests, e.g. CreateShootCluster.
+	RetryPost bool
+}
+
+// DefaultRetryPolicy returns the retry policy applied by New, and so by NewClient and NewClientNoPassword,
+// unless WithRetryPolicy is given.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+var retryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Return max number of attempts allowed for a given request method.
+func (p *RetryPolicy) attempts(method string) int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead:
+		return p.MaxAttempts
+	case http.MethodPost:
+		if p.RetryPost {
+			return p.MaxAttempts
+		}
+	}
+	return 1
+}
+
+// Check if error returned by a single request attempt is worth retrying.
+func (p *RetryPolicy) retryable(err error) bool {
+	var re *RequestAPIError
+	if errors.As(err, &re) {
+		for _, code := range retryableStatusCodes {
+			if re.StatusCode == code {
+				return true
+			}
+		}
+		return false
+	}
+	// Transport error
+	return true
+}
+
+// Calculate wait duration before the next attempt. Exponential backoff with jitter,
+// Retry-After from the server takes precedence if set.
+func (p *RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+		return retryAfter
+	}
+	wait := p.InitialBackoff << (attempt - 1)
+	if p.MaxBackoff > 0 && (wait > p.MaxBackoff || wait <= 0) {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Randomize within [wait/2, wait)
+	half := wait / 2
+	return half + rand.N(wait-half)
+}
+
+// Parse Retry-After header value, either delay in seconds or HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
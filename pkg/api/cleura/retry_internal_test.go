@@ -0,0 +1,84 @@
+package cleura
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter time.Duration
+		min        time.Duration
+		max        time.Duration
+	}{
+		{name: "first retry", attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "doubled on every attempt", attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "capped by max backoff", attempt: 8, min: 500 * time.Millisecond, max: time.Second},
+		{name: "shift overflow capped by max backoff", attempt: 80, min: 500 * time.Millisecond, max: time.Second},
+		{name: "retry-after used as is", attempt: 1, retryAfter: 700 * time.Millisecond, min: 700 * time.Millisecond, max: 700 * time.Millisecond},
+		{name: "retry-after capped by max backoff", attempt: 1, retryAfter: time.Minute, min: time.Second, max: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 50 {
+				got := policy.backoff(tt.attempt, tt.retryAfter)
+				if got < tt.min || got > tt.max {
+					t.Fatalf("backoff(%d, %s) = %s, want between %s and %s", tt.attempt, tt.retryAfter, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyAttempts(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *RetryPolicy
+		method string
+		want   int
+	}{
+		{name: "nil policy", policy: nil, method: http.MethodGet, want: 1},
+		{name: "zero attempts", policy: &RetryPolicy{}, method: http.MethodGet, want: 1},
+		{name: "get", policy: &RetryPolicy{MaxAttempts: 4}, method: http.MethodGet, want: 4},
+		{name: "put", policy: &RetryPolicy{MaxAttempts: 4}, method: http.MethodPut, want: 4},
+		{name: "delete", policy: &RetryPolicy{MaxAttempts: 4}, method: http.MethodDelete, want: 4},
+		{name: "post", policy: &RetryPolicy{MaxAttempts: 4}, method: http.MethodPost, want: 1},
+		{name: "post with RetryPost", policy: &RetryPolicy{MaxAttempts: 4, RetryPost: true}, method: http.MethodPost, want: 4},
+		{name: "patch", policy: &RetryPolicy{MaxAttempts: 4, RetryPost: true}, method: http.MethodPatch, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.attempts(tt.method); got != tt.want {
+				t.Errorf("attempts(%s) = %d, want %d", tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{name: "empty", value: "", min: 0, max: 0},
+		{name: "seconds", value: "3", min: 3 * time.Second, max: 3 * time.Second},
+		{name: "zero seconds", value: "0", min: 0, max: 0},
+		{name: "negative seconds", value: "-5", min: 0, max: 0},
+		{name: "http date", value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), min: 58 * time.Second, max: time.Minute},
+		{name: "http date in the past", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), min: 0, max: 0},
+		{name: "garbage", value: "soon", min: 0, max: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value); got < tt.min || got > tt.max {
+				t.Errorf("parseRetryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.min, tt.max)
+			}
+		})
+	}
+}
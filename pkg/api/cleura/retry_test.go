@@ -0,0 +1,142 @@
+package cleura_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+// Fails the first `failures` requests with `status`, then serves requests by the fake API.
+type flakyHandler struct {
+	api        *cleuratest.API
+	failures   int32
+	status     int
+	retryAfter string
+	calls      atomic.Int32
+}
+
+func (h *flakyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.calls.Add(1) <= h.failures {
+		if h.retryAfter != "" {
+			w.Header().Set("Retry-After", h.retryAfter)
+		}
+		http.Error(w, http.StatusText(h.status), h.status)
+		return
+	}
+	h.api.ServeHTTP(w, r)
+}
+
+// Start fake API failing first requests and return client with a fast retry policy.
+func newFlakyClient(t *testing.T, h *flakyHandler, policy *cleura.RetryPolicy) *cleura.Client {
+	t.Helper()
+	h.api = cleuratest.NewAPI()
+	h.api.SetOperationDuration(0)
+	h.api.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, cleura.ShootClusterResponse{
+		Metadata: cleura.MetadataFieldsResponse{Name: "demo"},
+	})
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	client, err := cleura.New(
+		cleura.WithHostURL(srv.URL),
+		cleura.WithToken(cleuratest.DefaultUsername, cleuratest.DefaultToken),
+		cleura.WithRetryPolicy(policy),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestClientRetry(t *testing.T) {
+	fast := &cleura.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	fastPost := *fast
+	fastPost.RetryPost = true
+	listDomains := func(c *cleura.Client) error {
+		_, err := c.ListDomains(context.Background())
+		return err
+	}
+	hibernate := func(c *cleura.Client) error {
+		return c.HibernateCluster(context.Background(), cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, "demo")
+	}
+	tests := []struct {
+		name      string
+		policy    *cleura.RetryPolicy
+		failures  int32
+		status    int
+		call      func(c *cleura.Client) error
+		wantCalls int32
+		wantErr   error
+	}{
+		{name: "get retried until success", policy: fast, failures: 2, status: http.StatusServiceUnavailable, call: listDomains, wantCalls: 3},
+		{name: "get retried on rate limit", policy: fast, failures: 1, status: http.StatusTooManyRequests, call: listDomains, wantCalls: 2},
+		{name: "get gives up after max attempts", policy: fast, failures: 5, status: http.StatusBadGateway, call: listDomains, wantCalls: 3, wantErr: cleura.ErrServer},
+		{name: "client errors are not retried", policy: fast, failures: 5, status: http.StatusNotFound, call: listDomains, wantCalls: 1, wantErr: cleura.ErrNotFound},
+		{name: "internal server error is not retried", policy: fast, failures: 5, status: http.StatusInternalServerError, call: listDomains, wantCalls: 1, wantErr: cleura.ErrServer},
+		{name: "post not retried by default", policy: fast, failures: 1, status: http.StatusServiceUnavailable, call: hibernate, wantCalls: 1, wantErr: cleura.ErrServer},
+		{name: "post retried with RetryPost", policy: &fastPost, failures: 1, status: http.StatusServiceUnavailable, call: hibernate, wantCalls: 2},
+		{name: "nil policy makes a single attempt", policy: nil, failures: 1, status: http.StatusServiceUnavailable, call: listDomains, wantCalls: 1, wantErr: cleura.ErrServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &flakyHandler{failures: tt.failures, status: tt.status}
+			client := newFlakyClient(t, h, tt.policy)
+			err := tt.call(client)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got := h.calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		maxBackoff time.Duration
+		minWait    time.Duration
+		maxWait    time.Duration
+	}{
+		{name: "retry-after takes precedence over backoff", retryAfter: "1", maxBackoff: time.Minute, minWait: time.Second, maxWait: 5 * time.Second},
+		{name: "retry-after is capped by max backoff", retryAfter: "120", maxBackoff: 10 * time.Millisecond, minWait: 0, maxWait: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &flakyHandler{failures: 1, status: http.StatusTooManyRequests, retryAfter: tt.retryAfter}
+			client := newFlakyClient(t, h, &cleura.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: tt.maxBackoff})
+			start := time.Now()
+			if _, err := client.ListDomains(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minWait || elapsed > tt.maxWait {
+				t.Errorf("waited %s, want between %s and %s", elapsed, tt.minWait, tt.maxWait)
+			}
+		})
+	}
+}
+
+func TestClientRetryStopsOnContextCancel(t *testing.T) {
+	h := &flakyHandler{failures: 5, status: http.StatusServiceUnavailable}
+	client := newFlakyClient(t, h, &cleura.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Minute, MaxBackoff: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.ListDomains(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := h.calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
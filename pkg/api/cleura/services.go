@@ -0,0 +1,54 @@
+package cleura
+
+import "context"
+
+// Interfaces implemented by Client. Depend on the narrowest interface needed, so that
+// Client can be replaced by a mock (see package cleuramock) in tests.
+
+// AuthService groups authentication methods of Client.
+type AuthService interface {
+	GetToken(ctx context.Context) (*AuthResponse, error)
+	RevokeToken(ctx context.Context) error
+	ValidateToken(ctx context.Context) error
+	Request2FactorCode(ctx context.Context) error
+	GetTokenWith2FA(ctx context.Context, twoFACodeFromSms int) error
+}
+
+// AccessControlService groups openstack domain and project methods of Client.
+type AccessControlService interface {
+	ListDomains(ctx context.Context) (*[]OpenstackDomain, error)
+	ListProjects(ctx context.Context, domain_id string) (*[]OpenstackProject, error)
+}
+
+// ShootService groups shoot cluster methods of Client.
+type ShootService interface {
+	GetShootCluster(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (*ShootClusterResponse, error)
+	ListShootClusters(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string) ([]ShootClusterResponse, error)
+	CreateShootCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, shootClusterRequest ShootClusterRequest) (*ShootClusterCreateResponse, error)
+	UpdateShootCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string, shootClusterUpdateRequest ShootClusterRequest) (*ShootClusterResponse, error)
+	DeleteShootCluster(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (string, error)
+	EnableHaControlPlane(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) (bool, error)
+	AddWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerGroupRequest WorkerGroupRequest) (*ShootClusterResponse, error)
+	UpdateWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string, workerGroupRequest WorkerGroupRequest) (*ShootClusterResponse, error)
+	DeleteWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string) (*ShootClusterResponse, error)
+	HibernateCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error
+	WakeUpCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error
+	GenerateKubeConfig(ctx context.Context, gardenDomain, clusterRegion string, clusterProject string, clusterName string, durationSeconds int64) ([]byte, error)
+	GetKubeConfig(ctx context.Context, gardenDomain, clusterRegion string, clusterProject string, clusterName string) ([]byte, error)
+	GetMonitoringCredentials(ctx context.Context, gardenDomain, clusterRegion string, clusterProject string, clusterName string) ([]byte, error)
+}
+
+// CloudProfileService groups cloud profile methods of Client.
+type CloudProfileService interface {
+	GetCloudProfile(ctx context.Context, gardenDomain string) (*CloudProfile, error)
+}
+
+// API groups all services implemented by Client.
+type API interface {
+	AuthService
+	AccessControlService
+	ShootService
+	CloudProfileService
+}
+
+var _ API = (*Client)(nil)
@@ -1,14 +1,15 @@
 package cleura
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetShootCluster(gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (*ShootClusterResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
+func (c *Client) GetShootCluster(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (*ShootClusterResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
 	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shootName
 	if err != nil {
 		return nil, err
@@ -26,8 +27,8 @@ func (c *Client) GetShootCluster(gardenDomain string, clusterName string, cluste
 	return &shoot, nil
 }
 
-func (c *Client) ListShootClusters(gardenDomain string, clusterRegion string, clusterProject string) ([]ShootClusterResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject), nil)
+func (c *Client) ListShootClusters(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string) ([]ShootClusterResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject), nil)
 	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project
 	if err != nil {
 		return nil, err
@@ -45,13 +46,13 @@ func (c *Client) ListShootClusters(gardenDomain string, clusterRegion string, cl
 	return shoots, nil
 }
 
-func (c *Client) CreateShootCluster(gardenDomain string, clusterRegion string, clusterProject string, shootClusterRequest ShootClusterRequest) (*ShootClusterCreateResponse, error) {
+func (c *Client) CreateShootCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, shootClusterRequest ShootClusterRequest) (*ShootClusterCreateResponse, error) {
 	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project
 	crJsonByte, err := json.Marshal(shootClusterRequest)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject), strings.NewReader(string(crJsonByte)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject), strings.NewReader(string(crJsonByte)))
 	if err != nil {
 		return nil, err
 	}
@@ -68,9 +69,9 @@ func (c *Client) CreateShootCluster(gardenDomain string, clusterRegion string, c
 	return &createdShootCluster, nil
 }
 
-func (c *Client) DeleteShootCluster(gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (string, error) {
+func (c *Client) DeleteShootCluster(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (string, error) {
 	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
 	if err != nil {
 		return "", err
 	}
@@ -81,13 +82,13 @@ func (c *Client) DeleteShootCluster(gardenDomain string, clusterName string, clu
 	return string(body), nil
 }
 
-func (c *Client) UpdateShootCluster(gardenDomain string, clusterRegion string, clusterProject string, clusterName string, shootClusterUpdateRequest ShootClusterRequest) (*ShootClusterResponse, error) {
+func (c *Client) UpdateShootCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string, shootClusterUpdateRequest ShootClusterRequest) (*ShootClusterResponse, error) {
 	crJsonByte, err := json.Marshal(shootClusterUpdateRequest)
 	if err != nil {
 		return nil, err
 	}
 	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), strings.NewReader(string(crJsonByte)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), strings.NewReader(string(crJsonByte)))
 	if err != nil {
 		return nil, err
 	}
@@ -104,10 +105,12 @@ func (c *Client) UpdateShootCluster(gardenDomain string, clusterRegion string, c
 	return &createdShootCluster, nil
 }
 
-func (c *Client) EnableHaControlPlane(gardenDomain string, clusterRegion string, clusterProject string, clusterName string, shootClusterUpdateRequest ShootClusterRequest) (bool, error) {
+// EnableHaControlPlane enables highly available control plane. Failure tolerance is chosen by the API and
+// reported in Spec.ControlPlane of the shoot cluster.
+func (c *Client) EnableHaControlPlane(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) (bool, error) {
 
-	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/enable-ha-control-plane", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
+	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot/enable-ha-control-plane
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/enable-ha-control-plane", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
 	if err != nil {
 		return false, err
 	}
@@ -119,13 +122,13 @@ func (c *Client) EnableHaControlPlane(gardenDomain string, clusterRegion string,
 
 }
 
-func (c *Client) AddWorkerGroup(gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerGroupRequest WorkerGroupRequest) (*ShootClusterResponse, error) {
+func (c *Client) AddWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerGroupRequest WorkerGroupRequest) (*ShootClusterResponse, error) {
 	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot/worker
 	wgrJsonByte, err := json.Marshal(workerGroupRequest)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/worker", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), strings.NewReader(string(wgrJsonByte)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/worker", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), strings.NewReader(string(wgrJsonByte)))
 	if err != nil {
 		return nil, err
 	}
@@ -142,13 +145,13 @@ func (c *Client) AddWorkerGroup(gardenDomain string, clusterName string, cluster
 	return &updatedShootCluster, nil
 }
 
-func (c *Client) UpdateWorkerGroup(gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string, workerGroupRequest WorkerGroupRequest) (*ShootClusterResponse, error) {
+func (c *Client) UpdateWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string, workerGroupRequest WorkerGroupRequest) (*ShootClusterResponse, error) {
 	// https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot/worker/:workerName
 	wgrJsonByte, err := json.Marshal(workerGroupRequest)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/worker/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName, workerName), strings.NewReader(string(wgrJsonByte)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/worker/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName, workerName), strings.NewReader(string(wgrJsonByte)))
 	if err != nil {
 		return nil, err
 	}
@@ -165,10 +168,10 @@ func (c *Client) UpdateWorkerGroup(gardenDomain string, clusterName string, clus
 	return &updatedShootCluster, nil
 }
 
-func (c *Client) DeleteWorkerGroup(gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string) (*ShootClusterResponse, error) {
+func (c *Client) DeleteWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string) (*ShootClusterResponse, error) {
 	//https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot/worker/:worker
 
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/worker/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName, workerName), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/worker/%s", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName, workerName), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +187,7 @@ func (c *Client) DeleteWorkerGroup(gardenDomain string, clusterName string, clus
 	return &updatedShootCluster, nil
 }
 
-func (c *Client) GenerateKubeConfig(gardenDomain, clusterRegion string, clusterProject string, clusterName string, durationSeconds int64) ([]byte, error) {
+func (c *Client) GenerateKubeConfig(ctx context.Context, gardenDomain, clusterRegion string, clusterProject string, clusterName string, durationSeconds int64) ([]byte, error) {
 	//https://rest.cleura.cloud/gardener/v1/public/shoot/kna1/b5d2bf2c162444f4918aaa4cb534a612/myshoot/adminkubeconfig
 
 	type Config struct {
@@ -202,7 +205,7 @@ func (c *Client) GenerateKubeConfig(gardenDomain, clusterRegion string, clusterP
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/adminkubeconfig", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), strings.NewReader(string(requestJsonByte)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/adminkubeconfig", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), strings.NewReader(string(requestJsonByte)))
 	if err != nil {
 		return nil, err
 	}
@@ -213,10 +216,10 @@ func (c *Client) GenerateKubeConfig(gardenDomain, clusterRegion string, clusterP
 	return body, nil
 }
 
-func (c *Client) GetKubeConfig(gardenDomain, clusterRegion string, clusterProject string, clusterName string) ([]byte, error) {
+func (c *Client) GetKubeConfig(ctx context.Context, gardenDomain, clusterRegion string, clusterProject string, clusterName string) ([]byte, error) {
 	// https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shootName/Kubeconfig
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/kubeconfig", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/kubeconfig", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -227,10 +230,10 @@ func (c *Client) GetKubeConfig(gardenDomain, clusterRegion string, clusterProjec
 	return body, nil
 }
 
-func (c *Client) GetMonitoringCredentials(gardenDomain, clusterRegion string, clusterProject string, clusterName string) ([]byte, error) {
+func (c *Client) GetMonitoringCredentials(ctx context.Context, gardenDomain, clusterRegion string, clusterProject string, clusterName string) ([]byte, error) {
 	// https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shootName/Kubeconfig
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/monitoring", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/monitoring", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -242,9 +245,9 @@ func (c *Client) GetMonitoringCredentials(gardenDomain, clusterRegion string, cl
 }
 
 // Hibernate.
-func (c *Client) HibernateCluster(gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error {
+func (c *Client) HibernateCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error {
 	// https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot/hibernate
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/hibernate", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/hibernate", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
 	if err != nil {
 		return err
 	}
@@ -256,9 +259,9 @@ func (c *Client) HibernateCluster(gardenDomain string, clusterRegion string, clu
 }
 
 // Wake up call.
-func (c *Client) WakeUpCluster(gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error {
+func (c *Client) WakeUpCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error {
 	// https://rest.cleura.cloud/gardener/v1/:gardenDomain/shoot/:region/:project/:shoot/wakeup
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/wakeup", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/gardener/v1/%s/shoot/%s/%s/%s/wakeup", c.HostURL, gardenDomain, clusterRegion, clusterProject, clusterName), nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,137 @@
+package cleura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// TokenSource supplies API tokens to the client.
+type TokenSource interface {
+	// Token returns a token to authenticate requests with.
+	Token(ctx context.Context) (string, error)
+}
+
+// RefreshableTokenSource is a TokenSource able to obtain a new token once
+// the current one is rejected by the API. Client calls Refresh at most once per request.
+type RefreshableTokenSource interface {
+	TokenSource
+	Refresh(ctx context.Context) (string, error)
+}
+
+type staticTokenSource string
+
+// StaticTokenSource returns a TokenSource that always returns the same token.
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+func (s staticTokenSource) Token(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+// TokenSourceFunc adapts a callback to RefreshableTokenSource. The callback is invoked with
+// refresh set to true if previously returned token was rejected.
+type TokenSourceFunc func(ctx context.Context, refresh bool) (string, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx, false)
+}
+
+func (f TokenSourceFunc) Refresh(ctx context.Context) (string, error) {
+	return f(ctx, true)
+}
+
+type passwordTokenSource struct {
+	client *Client
+}
+
+// PasswordTokenSource returns a TokenSource logging in with username and password on every
+// Token/Refresh call. Options configure the client used for login requests.
+// Accounts with two-factor authentication enabled are not supported.
+func PasswordTokenSource(username, password string, opts ...Option) (RefreshableTokenSource, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("define username and password")
+	}
+	client, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	client.Auth = AuthStruct{
+		Username: username,
+		Password: password,
+	}
+	return &passwordTokenSource{client: client}, nil
+}
+
+func (s *passwordTokenSource) Token(ctx context.Context) (string, error) {
+	ar, err := s.client.GetToken(ctx)
+	if err != nil {
+		return "", fmt.Errorf("login failed: %w", err)
+	}
+	return ar.Token, nil
+}
+
+func (s *passwordTokenSource) Refresh(ctx context.Context) (string, error) {
+	return s.Token(ctx)
+}
+
+type fileTokenSource struct {
+	mu     sync.Mutex
+	path   string
+	source RefreshableTokenSource
+}
+
+// FileTokenSource returns a TokenSource caching tokens from source in a file on disk,
+// so that the token is reused between runs. Source is consulted if the file is missing,
+// empty or the cached token is rejected.
+func FileTokenSource(path string, source RefreshableTokenSource) RefreshableTokenSource {
+	return &fileTokenSource{
+		path:   path,
+		source: source,
+	}
+}
+
+func (s *fileTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	if token := strings.TrimSpace(string(data)); token != "" {
+		return token, nil
+	}
+	token, err := s.source.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	return token, s.store(token)
+}
+
+func (s *fileTokenSource) Refresh(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, err := s.source.Refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+	return token, s.store(token)
+}
+
+// Write token to the cache file.
+func (s *fileTokenSource) store(token string) error {
+	err := os.MkdirAll(filepath.Dir(s.path), 0700)
+	if err != nil {
+		return fmt.Errorf("caching token failed: %w", err)
+	}
+	err = os.WriteFile(s.path, []byte(token), 0600)
+	if err != nil {
+		return fmt.Errorf("caching token failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package cleura_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+// Transport counting requests by method and path.
+type countingTransport struct {
+	mu     sync.Mutex
+	next   http.RoundTripper
+	counts map[string]int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	if t.counts == nil {
+		t.counts = map[string]int{}
+	}
+	t.counts[r.Method+" "+r.URL.Path]++
+	t.mu.Unlock()
+	return t.next.RoundTrip(r)
+}
+
+func (t *countingTransport) count(request string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.counts[request]
+}
+
+const (
+	listDomainsRequest = "GET /accesscontrol/v1/openstack/domains"
+	loginRequest       = "POST /auth/v1/tokens"
+)
+
+// Token source returning DefaultToken and `refreshed` tokens once refreshed, counting refreshes.
+type countingTokenSource struct {
+	mu        sync.Mutex
+	refreshed []string
+	refreshes int
+	err       error
+}
+
+func (s *countingTokenSource) source(_ context.Context, refresh bool) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !refresh {
+		return cleuratest.DefaultToken, nil
+	}
+	s.refreshes++
+	if s.err != nil {
+		return "", s.err
+	}
+	return s.refreshed[min(s.refreshes, len(s.refreshed))-1], nil
+}
+
+func newCountingClient(t *testing.T, srv *cleuratest.Server, source cleura.TokenSource) (*cleura.Client, *countingTransport) {
+	t.Helper()
+	counter := &countingTransport{next: srv.Client().Transport}
+	client, err := srv.CleuraClient(
+		cleura.WithHTTPClient(&http.Client{Transport: counter}),
+		cleura.WithTokenSource(cleuratest.DefaultUsername, source),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, counter
+}
+
+func TestClientReauthentication(t *testing.T) {
+	refreshFailed := errors.New("login refused")
+	tests := []struct {
+		name      string
+		source    func(s *countingTokenSource) cleura.TokenSource
+		refreshed []string
+		refresh   error
+		requests  int
+		refreshes int
+		wantErr   []error
+		errText   string
+	}{
+		{
+			name:      "rejected token is refreshed and request retried",
+			source:    func(s *countingTokenSource) cleura.TokenSource { return cleura.TokenSourceFunc(s.source) },
+			refreshed: []string{"fresh-token"},
+			requests:  2,
+			refreshes: 1,
+		},
+		{
+			name:      "request is retried only once",
+			source:    func(s *countingTokenSource) cleura.TokenSource { return cleura.TokenSourceFunc(s.source) },
+			refreshed: []string{"rejected-token"},
+			requests:  2,
+			refreshes: 1,
+			wantErr:   []error{cleura.ErrUnauthorized},
+		},
+		{
+			name: "static token is not refreshed",
+			source: func(s *countingTokenSource) cleura.TokenSource {
+				return cleura.StaticTokenSource(cleuratest.DefaultToken)
+			},
+			requests: 1,
+			wantErr:  []error{cleura.ErrUnauthorized},
+		},
+		{
+			name:      "refresh failure",
+			source:    func(s *countingTokenSource) cleura.TokenSource { return cleura.TokenSourceFunc(s.source) },
+			refresh:   refreshFailed,
+			requests:  1,
+			refreshes: 1,
+			wantErr:   []error{refreshFailed, cleura.ErrUnauthorized},
+			errText:   "re-authentication failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := cleuratest.NewServer(nil)
+			defer srv.Close()
+			srv.API.AddToken(cleuratest.DefaultUsername, "fresh-token")
+			source := &countingTokenSource{refreshed: tt.refreshed, err: tt.refresh}
+			client, counter := newCountingClient(t, srv, tt.source(source))
+			srv.API.RevokeToken(cleuratest.DefaultToken)
+			_, err := client.ListDomains(context.Background())
+			for _, want := range tt.wantErr {
+				if !errors.Is(err, want) {
+					t.Errorf("error = %v, want %v", err, want)
+				}
+			}
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Errorf("error = %v", err)
+			}
+			if tt.errText != "" && (err == nil || !strings.Contains(err.Error(), tt.errText)) {
+				t.Errorf("error = %v, want containing %q", err, tt.errText)
+			}
+			if n := counter.count(listDomainsRequest); n != tt.requests {
+				t.Errorf("sent %d requests, want %d", n, tt.requests)
+			}
+			if source.refreshes != tt.refreshes {
+				t.Errorf("refreshed %d times, want %d", source.refreshes, tt.refreshes)
+			}
+			if err == nil {
+				if token, _ := client.AccessToken(context.Background()); token != "fresh-token" {
+					t.Errorf("token after refresh = %q, want fresh-token", token)
+				}
+			}
+		})
+	}
+}
+
+// Concurrent requests rejected with the same token trigger a single login.
+func TestClientReauthenticationConcurrent(t *testing.T) {
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	counter := &countingTransport{next: srv.Client().Transport}
+	client, err := srv.CleuraClient(
+		cleura.WithHTTPClient(&http.Client{Transport: counter}),
+		cleura.WithCredentials(cleuratest.DefaultUsername, cleuratest.DefaultPassword),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	token, err := client.AccessToken(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.API.RevokeToken(token)
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ListDomains(ctx)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if n := counter.count(loginRequest); n != 2 {
+		t.Errorf("logged in %d times, want 2", n)
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "token")
+	source := &countingTokenSource{refreshed: []string{"fresh-token"}}
+	ctx := context.Background()
+	cached := cleura.FileTokenSource(path, cleura.TokenSourceFunc(source.source))
+	token, err := cached.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != cleuratest.DefaultToken {
+		t.Errorf("token = %q, want %q", token, cleuratest.DefaultToken)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("cache file permissions = %o, want 600", perm)
+	}
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || info.Mode().Perm() != 0700 {
+		t.Errorf("cache directory = %v, %v, want permissions 700", info, err)
+	}
+
+	// Cached token is used by later runs, source is consulted only to refresh it
+	reused := cleura.FileTokenSource(path, cleura.TokenSourceFunc(func(context.Context, bool) (string, error) {
+		return "", errors.New("source must not be used")
+	}))
+	if token, err := reused.Token(ctx); err != nil || token != cleuratest.DefaultToken {
+		t.Errorf("cached token = %q, %v, want %q", token, err, cleuratest.DefaultToken)
+	}
+	if token, err := cached.Refresh(ctx); err != nil || token != "fresh-token" {
+		t.Errorf("refreshed token = %q, %v, want fresh-token", token, err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "fresh-token" {
+		t.Errorf("cache file = %q, %v, want fresh-token", data, err)
+	}
+	if source.refreshes != 1 {
+		t.Errorf("refreshed %d times, want 1", source.refreshes)
+	}
+
+	// Empty cache file is replaced with a token from the source
+	if err := os.WriteFile(path, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := cached.Token(ctx); err != nil || token != cleuratest.DefaultToken {
+		t.Errorf("token of empty cache = %q, %v, want %q", token, err, cleuratest.DefaultToken)
+	}
+}
+
+// Client refreshes a rejected cached token and caches the new one.
+func TestClientFileTokenSource(t *testing.T) {
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("expired-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	login, err := cleura.PasswordTokenSource(cleuratest.DefaultUsername, cleuratest.DefaultPassword,
+		cleura.WithHostURL(srv.URL), cleura.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, counter := newCountingClient(t, srv, cleura.FileTokenSource(path, login))
+	if _, err := client.ListDomains(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := counter.count(listDomainsRequest); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := client.AccessToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != token || token == "expired-token" {
+		t.Errorf("cached token = %q, client token %q, want new token cached", data, token)
+	}
+}
@@ -0,0 +1,343 @@
+package cleura
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRequest is matched by errors returned from request validation.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// Max length of a worker group name accepted by Cleura API.
+const maxWorkerGroupNameLength = 6
+
+// Max number of suggestions offered for a single problem.
+const maxSuggestions = 3
+
+// ValidationProblem describes a single problem found in a request field.
+type ValidationProblem struct {
+	// Path to the field, ex: "shoot.provider.workers[0].machine.type".
+	Field   string
+	Message string
+	// Values that would be accepted instead, best match first.
+	Suggestions []string
+}
+
+func (p ValidationProblem) String() string {
+	s := fmt.Sprintf("%s: %s", p.Field, p.Message)
+	if len(p.Suggestions) > 0 {
+		s += fmt.Sprintf(" (did you mean: %s)", strings.Join(p.Suggestions, ", "))
+	}
+	return s
+}
+
+// ValidationError holds every problem found in a request.
+type ValidationError struct {
+	Problems []ValidationProblem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		lines = append(lines, p.String())
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidRequest, strings.Join(lines, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidRequest
+}
+
+// Collects problems found during validation. Cloud profile checks are skipped if spec is nil.
+type validator struct {
+	spec     *CloudProfileSpec
+	region   string
+	now      time.Time
+	problems []ValidationProblem
+}
+
+func newValidator(profile *CloudProfile, region string) *validator {
+	v := &validator{region: region, now: time.Now()}
+	if profile != nil {
+		v.spec = &profile.Spec
+	}
+	return v
+}
+
+func (v *validator) add(field string, suggestions []string, format string, args ...any) {
+	v.problems = append(v.problems, ValidationProblem{
+		Field:       field,
+		Message:     fmt.Sprintf(format, args...),
+		Suggestions: suggestions,
+	})
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+// ValidateShootClusterRequest checks a shoot cluster request for missing or malformed fields and checks
+// its Kubernetes version, machine types, images, zones and volumes against the cloud profile. Zones are checked
+// against `region`, or against zones of every region if it is empty. Cloud profile checks are skipped if profile
+// is nil. Returns *ValidationError listing all problems found, or nil.
+func ValidateShootClusterRequest(req ShootClusterRequest, profile *CloudProfile, region string) error {
+	v := newValidator(profile, region)
+	v.shoot(req.Shoot)
+	return v.err()
+}
+
+// ValidateWorkerGroupRequest checks a worker group request, see ValidateShootClusterRequest.
+func ValidateWorkerGroupRequest(req WorkerGroupRequest, profile *CloudProfile, region string) error {
+	v := newValidator(profile, region)
+	v.worker("worker", req.Worker)
+	return v.err()
+}
+
+func (v *validator) shoot(shoot ShootClusterRequestConfig) {
+	if shoot.Name == "" {
+		v.add("shoot.name", nil, "must not be empty")
+	}
+	if shoot.KubernetesVersion != nil {
+		v.kubernetesVersion("shoot.kubernetesVersion.version", shoot.KubernetesVersion.Version)
+	}
+	if v.spec != nil && v.region != "" {
+		if _, err := v.spec.Region(v.region); err != nil {
+			v.add("region", regionNames(*v.spec), "region %s is not offered", v.region)
+		}
+	}
+	if shoot.Maintenance != nil && shoot.Maintenance.TimeWindow != nil {
+		v.timeWindow("shoot.maintenance.timeWindow", *shoot.Maintenance.TimeWindow)
+	}
+	if shoot.Hibernation != nil {
+		for i, schedule := range shoot.Hibernation.HibernationSchedules {
+			if schedule.Start == "" && schedule.End == "" {
+				v.add(fmt.Sprintf("shoot.hibernation.schedules[%d]", i), nil, "at least one of start or end must be set")
+			}
+		}
+	}
+	if shoot.Provider == nil || len(shoot.Provider.Workers) == 0 {
+		v.add("shoot.provider.workers", nil, "at least one worker group is required")
+		return
+	}
+	if network := shoot.Provider.InfrastructureConfig.Networks; network != nil && (network.Id == "") != (network.Router.Id == "") {
+		v.add("shoot.provider.infrastructureConfig.networks", nil, "both network id and router id must be set")
+	}
+	names := make(map[string]bool)
+	for i, worker := range shoot.Provider.Workers {
+		path := fmt.Sprintf("shoot.provider.workers[%d]", i)
+		if worker.Name != "" {
+			if names[worker.Name] {
+				v.add(path+".name", nil, "duplicate worker group name %s", worker.Name)
+			}
+			names[worker.Name] = true
+		}
+		v.worker(path, worker)
+	}
+}
+
+// Maintenance time window bounds are formatted as HHMMSS+ZZZZ.
+var timeWindowPattern = regexp.MustCompile(`^([01][0-9]|2[0-3])[0-5][0-9][0-5][0-9][+-][0-9]{4}$`)
+
+func (v *validator) timeWindow(field string, window TimeWindowDetails) {
+	if !timeWindowPattern.MatchString(window.Begin) {
+		v.add(field+".begin", []string{"000000+0000"}, "invalid time %q, expected HHMMSS+ZZZZ", window.Begin)
+	}
+	if !timeWindowPattern.MatchString(window.End) {
+		v.add(field+".end", []string{"010000+0000"}, "invalid time %q, expected HHMMSS+ZZZZ", window.End)
+	}
+}
+
+func (v *validator) kubernetesVersion(field string, version string) {
+	if version == "" {
+		v.add(field, nil, "must not be empty")
+		return
+	}
+	if v.spec == nil {
+		return
+	}
+	var suggestions []string
+	if latest, err := v.spec.LatestSupportedKubernetesVersion(); err == nil {
+		suggestions = []string{latest.Version}
+	}
+	cp, err := v.spec.KubernetesVersion(version)
+	switch {
+	case err != nil:
+		v.add(field, suggestions, "kubernetes version %s is not offered", version)
+	case cp.Expired(v.now):
+		v.add(field, suggestions, "kubernetes version %s expired on %s", version, cp.ExpirationDate)
+	}
+}
+
+func (v *validator) worker(path string, w WorkerRequest) {
+	if len(w.Name) > maxWorkerGroupNameLength {
+		v.add(path+".name", nil, "worker group name must be no longer than %d characters", maxWorkerGroupNameLength)
+	}
+	if w.Minimum < 0 || w.Maximum < 0 {
+		v.add(path+".minimum", nil, "node counts must not be negative")
+	} else if w.Maximum < w.Minimum {
+		v.add(path+".maximum", nil, "maximum %d is less than minimum %d", w.Maximum, w.Minimum)
+	}
+	for i, taint := range w.Taints {
+		if !slices.Contains(taintEffects, taint.Effect) {
+			v.add(fmt.Sprintf("%s.taints[%d].effect", path, i), closestMatches(taint.Effect, taintEffects), "invalid taint effect %q", taint.Effect)
+		}
+	}
+	if w.Volume.Size != "" {
+		if size, err := parseQuantity(w.Volume.Size); err != nil || size <= 0 {
+			v.add(path+".volume.size", []string{"50Gi"}, "invalid volume size %q", w.Volume.Size)
+		}
+	}
+	if w.Machine.Type == "" {
+		v.add(path+".machine.type", nil, "must not be empty")
+	}
+	if w.Machine.Image.Name == "" || w.Machine.Image.Version == "" {
+		v.add(path+".machine.image", nil, "image name and version must be set")
+	}
+	if v.spec == nil {
+		return
+	}
+	if w.Machine.Type != "" {
+		v.machineType(path+".machine.type", w.Machine.Type)
+	}
+	if w.Machine.Image.Name != "" && w.Machine.Image.Version != "" {
+		v.machineImage(path+".machine.image", w.Machine.Image)
+	}
+	v.zones(path+".zones", w.Zones)
+	if w.Volume.Type != "" {
+		v.volumeType(path+".volume.type", w.Volume.Type, w.Zones)
+	}
+}
+
+// Taint effects accepted by Kubernetes.
+var taintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+func (v *validator) machineType(field string, name string) {
+	var usable []string
+	for _, m := range v.spec.MachineTypes {
+		if m.Usable {
+			usable = append(usable, m.Name)
+		}
+	}
+	m, err := v.spec.MachineType(name)
+	switch {
+	case errors.Is(err, ErrNotInCloudProfile):
+		v.add(field, closestMatches(name, usable), "machine type %s is not offered", name)
+	case err != nil:
+		v.add(field, nil, "%s", err)
+	case !m.Usable:
+		v.add(field, closestMatches(name, usable), "machine type %s is not usable", name)
+	}
+}
+
+func (v *validator) machineImage(field string, image ImageDetails) {
+	cpImage, err := v.spec.MachineImage(image.Name)
+	if err != nil {
+		var names []string
+		for _, i := range v.spec.MachineImages {
+			names = append(names, i.Name)
+		}
+		v.add(field+".name", closestMatches(image.Name, names), "machine image %s is not offered", image.Name)
+		return
+	}
+	var suggestions []string
+	if latest, err := v.spec.LatestMachineImageVersion(image.Name); err == nil {
+		suggestions = []string{latest.Version}
+	}
+	i := slices.IndexFunc(cpImage.Versions, func(cp CPVersion) bool { return cp.Version == image.Version })
+	switch {
+	case i < 0:
+		v.add(field+".version", suggestions, "version %s of machine image %s is not offered", image.Version, image.Name)
+	case cpImage.Versions[i].Expired(v.now):
+		v.add(field+".version", suggestions, "version %s of machine image %s expired on %s", image.Version, image.Name, cpImage.Versions[i].ExpirationDate)
+	}
+}
+
+func (v *validator) zones(field string, zones []string) {
+	var offered []string
+	for _, region := range v.spec.Regions {
+		if v.region != "" && region.Name != v.region {
+			continue
+		}
+		for _, zone := range region.Zones {
+			if !slices.Contains(offered, zone.Name) {
+				offered = append(offered, zone.Name)
+			}
+		}
+	}
+	for _, zone := range zones {
+		if !slices.Contains(offered, zone) {
+			v.add(field, closestMatches(zone, offered), "zone %s is not offered in region %s", zone, cmp.Or(v.region, "any region"))
+		}
+	}
+}
+
+// Check that volume type is available in every selected zone, or in every zone of the region if none are selected.
+// Zones offering the volume type are suggested.
+func (v *validator) volumeType(field string, volumeType string, selected []string) {
+	for _, region := range v.spec.Regions {
+		if v.region != "" && region.Name != v.region {
+			continue
+		}
+		var available []string
+		for _, zone := range region.Zones {
+			if zone.VolumeTypeAvailable(volumeType) {
+				available = append(available, zone.Name)
+			}
+		}
+		for _, zone := range region.Zones {
+			if (len(selected) == 0 || slices.Contains(selected, zone.Name)) && !zone.VolumeTypeAvailable(volumeType) {
+				format := "volume type %s is not available in zone %s of region %s"
+				if len(available) > 0 {
+					format += ", suggested zones offer it"
+				}
+				v.add(field, closestMatches(zone.Name, available), format, volumeType, zone.Name, region.Name)
+			}
+		}
+	}
+}
+
+func regionNames(spec CloudProfileSpec) []string {
+	var names []string
+	for _, region := range spec.Regions {
+		names = append(names, region.Name)
+	}
+	return names
+}
+
+// Return up to maxSuggestions candidates closest to value by edit distance.
+func closestMatches(value string, candidates []string) []string {
+	sorted := slices.Clone(candidates)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		return cmp.Compare(editDistance(value, a), editDistance(value, b))
+	})
+	return sorted[:min(len(sorted), maxSuggestions)]
+}
+
+// Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,51 @@
+package cleura
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "abc", b: "", want: 3},
+		{a: "", b: "abc", want: 3},
+		{a: "nova", b: "nova", want: 0},
+		{a: "nva", b: "nova", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "b.4c8g", b: "b.4c8gb", want: 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		candidates []string
+		want       []string
+	}{
+		{name: "no candidates", value: "x", candidates: nil, want: nil},
+		{name: "best match first", value: "zne", candidates: []string{"node", "zone"}, want: []string{"zone", "node"}},
+		{name: "ties keep candidate order", value: "ab", candidates: []string{"ax", "xb", "ab"}, want: []string{"ab", "ax", "xb"}},
+		{name: "limited to max suggestions", value: "a", candidates: []string{"a", "b", "c", "d", "e"}, want: []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := append([]string(nil), tt.candidates...)
+			if got := closestMatches(tt.value, tt.candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("closestMatches(%q, %v) = %v, want %v", tt.value, tt.candidates, got, tt.want)
+			}
+			if !reflect.DeepEqual(candidates, tt.candidates) {
+				t.Errorf("candidates modified to %v", tt.candidates)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package cleura_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+// Fetch cloud profile served by the fake API.
+func testCloudProfile(t *testing.T) *cleura.CloudProfile {
+	t.Helper()
+	srv := cleuratest.NewServer(nil)
+	t.Cleanup(srv.Close)
+	client, err := srv.CleuraClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err := client.GetCloudProfile(context.Background(), cleuratest.DefaultGardenDomain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return profile
+}
+
+func validWorker() cleura.WorkerRequest {
+	return cleura.WorkerRequest{
+		Name:    "sys",
+		Minimum: 1,
+		Maximum: 3,
+		Machine: cleura.MachineDetails{Type: "b.2c4gb", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+		Zones:   []string{"nova"},
+	}
+}
+
+func validationProblems(t *testing.T, err error) []cleura.ValidationProblem {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, cleura.ErrInvalidRequest) {
+		t.Fatalf("error = %v, want %v", err, cleura.ErrInvalidRequest)
+	}
+	var ve *cleura.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("error = %T, want *cleura.ValidationError", err)
+	}
+	return ve.Problems
+}
+
+func TestValidateWorkerGroupRequest(t *testing.T) {
+	profile := testCloudProfile(t)
+	tests := []struct {
+		name   string
+		modify func(w *cleura.WorkerRequest)
+		want   []cleura.ValidationProblem
+	}{
+		{name: "valid", modify: func(w *cleura.WorkerRequest) {}},
+		{
+			name:   "deprecated image version not yet expired",
+			modify: func(w *cleura.WorkerRequest) { w.Machine.Image.Version = "1443.10.0" },
+		},
+		{
+			name:   "name too long",
+			modify: func(w *cleura.WorkerRequest) { w.Name = "system1" },
+			want:   []cleura.ValidationProblem{{Field: "worker.name", Message: "worker group name must be no longer than 6 characters"}},
+		},
+		{
+			name:   "maximum below minimum",
+			modify: func(w *cleura.WorkerRequest) { w.Minimum, w.Maximum = 3, 1 },
+			want:   []cleura.ValidationProblem{{Field: "worker.maximum", Message: "maximum 1 is less than minimum 3"}},
+		},
+		{
+			name:   "misspelled machine type",
+			modify: func(w *cleura.WorkerRequest) { w.Machine.Type = "b.4c8g" },
+			want: []cleura.ValidationProblem{{
+				Field:       "worker.machine.type",
+				Message:     "machine type b.4c8g is not offered",
+				Suggestions: []string{"b.4c8gb", "b.2c4gb", "b.8c16gb"},
+			}},
+		},
+		{
+			name:   "unusable machine type",
+			modify: func(w *cleura.WorkerRequest) { w.Machine.Type = "b.1c2gb" },
+			want: []cleura.ValidationProblem{{
+				Field:       "worker.machine.type",
+				Message:     "machine type b.1c2gb is not usable",
+				Suggestions: []string{"b.2c4gb", "b.4c8gb", "b.8c16gb"},
+			}},
+		},
+		{
+			name:   "misspelled image name",
+			modify: func(w *cleura.WorkerRequest) { w.Machine.Image.Name = "gardenlnux" },
+			want: []cleura.ValidationProblem{{
+				Field:       "worker.machine.image.name",
+				Message:     "machine image gardenlnux is not offered",
+				Suggestions: []string{"gardenlinux"},
+			}},
+		},
+		{
+			name:   "unknown image version",
+			modify: func(w *cleura.WorkerRequest) { w.Machine.Image.Version = "1592.5.0" },
+			want: []cleura.ValidationProblem{{
+				Field:       "worker.machine.image.version",
+				Message:     "version 1592.5.0 of machine image gardenlinux is not offered",
+				Suggestions: []string{"1592.4.0"},
+			}},
+		},
+		{
+			name:   "misspelled zone",
+			modify: func(w *cleura.WorkerRequest) { w.Zones = []string{"nva"} },
+			want: []cleura.ValidationProblem{{
+				Field:       "worker.zones",
+				Message:     "zone nva is not offered in region sto2",
+				Suggestions: []string{"nova"},
+			}},
+		},
+		{
+			name:   "misspelled taint effect",
+			modify: func(w *cleura.WorkerRequest) { w.Taints = []cleura.Taint{{Key: "k", Value: "v", Effect: "NoSchedul"}} },
+			want: []cleura.ValidationProblem{{
+				Field:       "worker.taints[0].effect",
+				Message:     `invalid taint effect "NoSchedul"`,
+				Suggestions: []string{"NoSchedule", "NoExecute", "PreferNoSchedule"},
+			}},
+		},
+		{
+			name:   "invalid volume size",
+			modify: func(w *cleura.WorkerRequest) { w.Volume.Size = "lots" },
+			want:   []cleura.ValidationProblem{{Field: "worker.volume.size", Message: `invalid volume size "lots"`, Suggestions: []string{"50Gi"}}},
+		},
+		{
+			name:   "every problem is reported",
+			modify: func(w *cleura.WorkerRequest) { w.Machine.Type = ""; w.Machine.Image.Version = "" },
+			want: []cleura.ValidationProblem{
+				{Field: "worker.machine.type", Message: "must not be empty"},
+				{Field: "worker.machine.image", Message: "image name and version must be set"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := validWorker()
+			tt.modify(&w)
+			err := cleura.ValidateWorkerGroupRequest(cleura.WorkerGroupRequest{Worker: w}, profile, cleuratest.DefaultRegion)
+			if got := validationProblems(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problems = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWorkerGroupRequestExpiredImage(t *testing.T) {
+	profile := testCloudProfile(t)
+	w := validWorker()
+	w.Machine.Image.Version = "1312.3.0"
+	problems := validationProblems(t, cleura.ValidateWorkerGroupRequest(cleura.WorkerGroupRequest{Worker: w}, profile, cleuratest.DefaultRegion))
+	if len(problems) != 1 || problems[0].Field != "worker.machine.image.version" || !reflect.DeepEqual(problems[0].Suggestions, []string{"1592.4.0"}) {
+		t.Errorf("problems = %+v, want expired image version suggesting 1592.4.0", problems)
+	}
+}
+
+func TestValidateWorkerGroupRequestVolumeType(t *testing.T) {
+	profile := testCloudProfile(t)
+	profile.Spec.Regions = append(profile.Spec.Regions, cleura.CPRegion{
+		Name: "multi",
+		Zones: []cleura.CPZone{
+			{Name: "zone-a", UnavailableVolumeTypes: []string{"fast"}},
+			{Name: "zone-b"},
+			{Name: "zone-c", UnavailableVolumeTypes: []string{"fast", "slow"}},
+		},
+	})
+	tests := []struct {
+		name   string
+		region string
+		zones  []string
+		volume string
+		want   []cleura.ValidationProblem
+	}{
+		{name: "available in selected zone", region: "sto2", zones: []string{"nova"}, volume: "fast"},
+		{name: "available in every zone of region", region: "multi", volume: "standard"},
+		{name: "available in selected zone only", region: "multi", zones: []string{"zone-b"}, volume: "fast"},
+		{
+			name:   "unavailable in the only zone",
+			region: "kna1",
+			zones:  []string{"nova"},
+			volume: "fast",
+			want:   []cleura.ValidationProblem{{Field: "worker.volume.type", Message: "volume type fast is not available in zone nova of region kna1"}},
+		},
+		{
+			name:   "unavailable in one of selected zones",
+			region: "multi",
+			zones:  []string{"zone-a", "zone-b"},
+			volume: "fast",
+			want: []cleura.ValidationProblem{{
+				Field:       "worker.volume.type",
+				Message:     "volume type fast is not available in zone zone-a of region multi, suggested zones offer it",
+				Suggestions: []string{"zone-b"},
+			}},
+		},
+		{
+			name:   "unavailable in zones of region without selected zones",
+			region: "multi",
+			volume: "slow",
+			want: []cleura.ValidationProblem{{
+				Field:       "worker.volume.type",
+				Message:     "volume type slow is not available in zone zone-c of region multi, suggested zones offer it",
+				Suggestions: []string{"zone-a", "zone-b"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := validWorker()
+			w.Zones = tt.zones
+			w.Volume = cleura.VolumeDetails{Size: "50Gi", Type: tt.volume}
+			err := cleura.ValidateWorkerGroupRequest(cleura.WorkerGroupRequest{Worker: w}, profile, tt.region)
+			if got := validationProblems(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problems = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateShootClusterRequest(t *testing.T) {
+	profile := testCloudProfile(t)
+	shoot := func(version string, workers ...cleura.WorkerRequest) cleura.ShootClusterRequest {
+		return cleura.ShootClusterRequest{Shoot: cleura.ShootClusterRequestConfig{
+			Name:              "demo",
+			KubernetesVersion: &cleura.K8sVersion{Version: version},
+			Provider:          &cleura.ProviderDetailsRequest{Workers: workers},
+		}}
+	}
+	tests := []struct {
+		name      string
+		request   cleura.ShootClusterRequest
+		region    string
+		noProfile bool
+		want      []cleura.ValidationProblem
+	}{
+		{name: "valid", request: shoot("1.31.4", validWorker()), region: cleuratest.DefaultRegion},
+		{name: "deprecated version not yet expired", request: shoot("1.30.5", validWorker()), region: cleuratest.DefaultRegion},
+		{name: "profile checks skipped without profile", request: shoot("1.0.0", validWorker()), region: "nowhere", noProfile: true},
+		{
+			name:    "unknown kubernetes version",
+			request: shoot("1.31.9", validWorker()),
+			region:  cleuratest.DefaultRegion,
+			want:    []cleura.ValidationProblem{{Field: "shoot.kubernetesVersion.version", Message: "kubernetes version 1.31.9 is not offered", Suggestions: []string{"1.31.4"}}},
+		},
+		{
+			name:    "misspelled region",
+			request: shoot("1.31.4", validWorker()),
+			region:  "sto",
+			want: []cleura.ValidationProblem{
+				{Field: "region", Message: "region sto is not offered", Suggestions: []string{"sto2", "kna1"}},
+				{Field: "shoot.provider.workers[0].zones", Message: "zone nova is not offered in region sto"},
+			},
+		},
+		{
+			name:    "no workers",
+			request: shoot("1.31.4"),
+			region:  cleuratest.DefaultRegion,
+			want:    []cleura.ValidationProblem{{Field: "shoot.provider.workers", Message: "at least one worker group is required"}},
+		},
+		{
+			name:    "duplicate worker names",
+			request: shoot("1.31.4", validWorker(), validWorker()),
+			region:  cleuratest.DefaultRegion,
+			want:    []cleura.ValidationProblem{{Field: "shoot.provider.workers[1].name", Message: "duplicate worker group name sys"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := profile
+			if tt.noProfile {
+				p = nil
+			}
+			err := cleura.ValidateShootClusterRequest(tt.request, p, tt.region)
+			if got := validationProblems(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problems = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateShootClusterRequestExpiredKubernetesVersion(t *testing.T) {
+	profile := testCloudProfile(t)
+	req := cleura.ShootClusterRequest{Shoot: cleura.ShootClusterRequestConfig{
+		Name:              "demo",
+		KubernetesVersion: &cleura.K8sVersion{Version: "1.29.12"},
+		Provider:          &cleura.ProviderDetailsRequest{Workers: []cleura.WorkerRequest{validWorker()}},
+	}}
+	problems := validationProblems(t, cleura.ValidateShootClusterRequest(req, profile, cleuratest.DefaultRegion))
+	if len(problems) != 1 || problems[0].Field != "shoot.kubernetesVersion.version" || !reflect.DeepEqual(problems[0].Suggestions, []string{"1.31.4"}) {
+		t.Errorf("problems = %+v, want expired kubernetes version suggesting 1.31.4", problems)
+	}
+}
@@ -0,0 +1,137 @@
+package cleura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrOperationFailed is returned by WaitForShootOperation when shoot operation failed, errored or was aborted.
+var ErrOperationFailed = errors.New("shoot operation failed")
+
+// Shoot last operation states.
+const (
+	OperationStateProcessing = "Processing"
+	OperationStateSucceeded  = "Succeeded"
+	OperationStateError      = "Error"
+	OperationStateFailed     = "Failed"
+	OperationStatePending    = "Pending"
+	OperationStateAborted    = "Aborted"
+)
+
+// Default settings for WaitForShootOperation.
+const (
+	DefaultWaitInterval    = 15 * time.Second
+	DefaultWaitGracePeriod = 30 * time.Second
+)
+
+// WaitOptions configures WaitForShootOperation.
+type WaitOptions struct {
+	// Poll interval. Defaults to DefaultWaitInterval.
+	Interval time.Duration
+	// Max time to wait. No limit except ctx deadline if zero.
+	Timeout time.Duration
+	// Operation deletes the shoot. Not found response is treated as success.
+	Deleted bool
+	// Require all shoot conditions to be `True` before reporting success.
+	RequireHealthy bool
+	// Operation is started asynchronously and last operation of the shoot may still
+	// show a previous, succeeded operation for a while. Success is only reported once an
+	// unfinished operation has been seen or grace period has elapsed.
+	// Defaults to DefaultWaitGracePeriod.
+	GracePeriod time.Duration
+	// Called with every polled shoot state, can be used to report progress.
+	Progress func(shoot *ShootClusterResponse)
+}
+
+// WaitForShootOperation polls shoot cluster until its last operation succeeds, fails or times out.
+// Returns last observed shoot state. Returned shoot is nil when waiting for deletion succeeded.
+func (c *Client) WaitForShootOperation(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string, opts WaitOptions) (*ShootClusterResponse, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWaitInterval
+	}
+	if opts.GracePeriod <= 0 {
+		opts.GracePeriod = DefaultWaitGracePeriod
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	seenUnfinished := false
+	var shoot *ShootClusterResponse
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		current, err := c.GetShootCluster(ctx, gardenDomain, clusterName, clusterRegion, clusterProject)
+		switch {
+		case err == nil:
+			shoot = current
+			if opts.Progress != nil {
+				opts.Progress(shoot)
+			}
+			done, err := shootOperationDone(shoot, opts, seenUnfinished || time.Since(start) > opts.GracePeriod)
+			if done || err != nil {
+				return shoot, err
+			}
+			if !isFinishedOperationState(shoot.Status.LastOperation.State) {
+				seenUnfinished = true
+			}
+		case errors.Is(err, ErrNotFound) && opts.Deleted:
+			return nil, nil
+		case errors.Is(err, ErrNotFound) && time.Since(start) <= opts.GracePeriod:
+			// Newly created shoot may not be visible yet
+		case ctx.Err() != nil:
+			return shoot, fmt.Errorf("waiting for shoot `%s`: %w", clusterName, ctx.Err())
+		default:
+			return shoot, err
+		}
+		select {
+		case <-ctx.Done():
+			return shoot, fmt.Errorf("waiting for shoot `%s`: %w", clusterName, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check if operation is completed. Succeeded operation is only trusted if `settled` is set.
+func shootOperationDone(shoot *ShootClusterResponse, opts WaitOptions, settled bool) (bool, error) {
+	op := shoot.Status.LastOperation
+	switch op.State {
+	case OperationStateFailed, OperationStateError, OperationStateAborted:
+		msg := fmt.Sprintf("%s operation of shoot `%s` is %s", op.Type, shoot.Metadata.Name, op.State)
+		if op.Description != "" {
+			msg += ": " + op.Description
+		}
+		return true, fmt.Errorf("%w: %s", ErrOperationFailed, msg)
+	case OperationStateSucceeded:
+		if !settled || opts.Deleted {
+			return false, nil
+		}
+		if opts.RequireHealthy && !shoot.Status.Healthy() {
+			return false, nil
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func isFinishedOperationState(state string) bool {
+	switch state {
+	case OperationStateSucceeded, OperationStateFailed, OperationStateError, OperationStateAborted:
+		return true
+	}
+	return false
+}
+
+// Healthy reports if all shoot conditions have status `True`.
+func (s StatusFieldsResponse) Healthy() bool {
+	for _, condition := range s.Conditions {
+		if condition.Status != "True" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package cleura_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+// Clock of the fake API advanced by tests.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Fake API taking a minute of its clock for every operation.
+func newWaitTestServer(t *testing.T) (*cleuratest.Server, *cleura.Client, *testClock) {
+	t.Helper()
+	clock := &testClock{now: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+	api := cleuratest.NewAPI()
+	api.SetClock(clock.Now)
+	api.SetOperationDuration(time.Minute)
+	srv := cleuratest.NewServer(api)
+	t.Cleanup(srv.Close)
+	client, err := srv.CleuraClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client, clock
+}
+
+func waitForTestShoot(client *cleura.Client, opts cleura.WaitOptions) (*cleura.ShootClusterResponse, error) {
+	return waitForTestShootContext(context.Background(), client, opts)
+}
+
+func waitForTestShootContext(ctx context.Context, client *cleura.Client, opts cleura.WaitOptions) (*cleura.ShootClusterResponse, error) {
+	return client.WaitForShootOperation(ctx, cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, "demo", opts)
+}
+
+// Operation started after waiting began is awaited, the previous succeeded operation is not trusted.
+func TestWaitForShootOperationStartedLater(t *testing.T) {
+	srv, client, clock := newWaitTestServer(t)
+	srv.API.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, testShoot("demo", "Reconcile", cleura.OperationStateSucceeded, 100))
+	var states []string
+	shoot, err := waitForTestShoot(client, cleura.WaitOptions{
+		Interval:    time.Millisecond,
+		GracePeriod: time.Hour,
+		Progress: func(shoot *cleura.ShootClusterResponse) {
+			states = append(states, shoot.Status.LastOperation.Type+" "+shoot.Status.LastOperation.State)
+			if len(states) == 2 {
+				if err := client.HibernateCluster(context.Background(), cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, "demo"); err != nil {
+					t.Error(err)
+				}
+			}
+			clock.Advance(20 * time.Second)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shoot.Status.Hibernated || shoot.Status.LastOperation.State != cleura.OperationStateSucceeded {
+		t.Errorf("shoot = %+v, want hibernation succeeded", shoot.Status)
+	}
+	if len(states) < 4 || states[0] != "Reconcile Succeeded" || !strings.HasSuffix(states[2], cleura.OperationStateProcessing) {
+		t.Errorf("polled states %v, want previous operation, then hibernation in progress until it succeeded", states)
+	}
+}
+
+// Succeeded operation is trusted once grace period elapses without an unfinished operation.
+func TestWaitForShootOperationGracePeriod(t *testing.T) {
+	srv, client, _ := newWaitTestServer(t)
+	srv.API.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, testShoot("demo", "Reconcile", cleura.OperationStateSucceeded, 100))
+	start := time.Now()
+	polls := 0
+	_, err := waitForTestShoot(client, cleura.WaitOptions{
+		Interval:    time.Millisecond,
+		GracePeriod: 50 * time.Millisecond,
+		Progress:    func(*cleura.ShootClusterResponse) { polls++ },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || polls < 2 {
+		t.Errorf("returned after %s and %d polls, want to wait for the grace period", elapsed, polls)
+	}
+}
+
+// Shoot not found yet is polled again during grace period and reported after it.
+func TestWaitForShootOperationNotFound(t *testing.T) {
+	srv, client, _ := newWaitTestServer(t)
+	srv.API.SetOperationDuration(0)
+	time.AfterFunc(20*time.Millisecond, func() {
+		srv.API.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, testShoot("demo", "Create", cleura.OperationStateSucceeded, 100))
+	})
+	shoot, err := waitForTestShoot(client, cleura.WaitOptions{Interval: time.Millisecond, GracePeriod: 100 * time.Millisecond})
+	if err != nil || shoot == nil || shoot.Metadata.Name != "demo" {
+		t.Errorf("shoot appearing during grace period = %v, %v, want shoot demo", shoot, err)
+	}
+
+	start := time.Now()
+	_, err = client.WaitForShootOperation(context.Background(), cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, "missing",
+		cleura.WaitOptions{Interval: time.Millisecond, GracePeriod: 20 * time.Millisecond})
+	if !errors.Is(err, cleura.ErrNotFound) {
+		t.Errorf("error = %v, want %v", err, cleura.ErrNotFound)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("missing shoot reported after %s, want after grace period", elapsed)
+	}
+}
+
+func TestWaitForShootOperationDeleted(t *testing.T) {
+	srv, client, clock := newWaitTestServer(t)
+	srv.API.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, testShoot("demo", "Create", cleura.OperationStateSucceeded, 100))
+	if _, err := client.DeleteShootCluster(context.Background(), cleuratest.DefaultGardenDomain, "demo", cleuratest.DefaultRegion, cleuratest.DefaultProjectID); err != nil {
+		t.Fatal(err)
+	}
+	polls := 0
+	shoot, err := waitForTestShoot(client, cleura.WaitOptions{
+		Interval: time.Millisecond,
+		Deleted:  true,
+		Progress: func(*cleura.ShootClusterResponse) {
+			polls++
+			clock.Advance(20 * time.Second)
+		},
+	})
+	if err != nil || shoot != nil {
+		t.Errorf("waiting for deletion = %+v, %v, want nil shoot and no error", shoot, err)
+	}
+	if polls < 2 {
+		t.Errorf("polled %d times, want to poll until deleted", polls)
+	}
+}
+
+func TestWaitForShootOperationFailed(t *testing.T) {
+	for _, state := range []string{cleura.OperationStateFailed, cleura.OperationStateError, cleura.OperationStateAborted} {
+		t.Run(state, func(t *testing.T) {
+			srv, client, _ := newWaitTestServer(t)
+			shoot := testShoot("demo", "Reconcile", state, 40)
+			shoot.Status.LastOperation.Description = "quota exceeded"
+			srv.API.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, shoot)
+			got, err := waitForTestShoot(client, cleura.WaitOptions{Interval: time.Millisecond, GracePeriod: time.Hour})
+			if !errors.Is(err, cleura.ErrOperationFailed) {
+				t.Fatalf("error = %v, want %v", err, cleura.ErrOperationFailed)
+			}
+			if want := "Reconcile operation of shoot `demo` is " + state + ": quota exceeded"; !strings.Contains(err.Error(), want) {
+				t.Errorf("error = %v, want containing %q", err, want)
+			}
+			if got == nil || got.Status.LastOperation.State != state {
+				t.Errorf("shoot = %+v, want last observed state", got)
+			}
+		})
+	}
+}
+
+func TestWaitForShootOperationCanceled(t *testing.T) {
+	srv, client, _ := newWaitTestServer(t)
+	// Operation never progresses without a running simulated operation
+	srv.API.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, testShoot("demo", "Reconcile", cleura.OperationStateProcessing, 10))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	shoot, err := waitForTestShootContext(ctx, client, cleura.WaitOptions{Interval: time.Millisecond})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+	if shoot == nil || shoot.Status.LastOperation.State != cleura.OperationStateProcessing {
+		t.Errorf("shoot = %+v, want last observed state", shoot)
+	}
+
+	_, err = waitForTestShoot(client, cleura.WaitOptions{Interval: time.Millisecond, Timeout: 20 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error after timeout = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
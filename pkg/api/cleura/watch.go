@@ -0,0 +1,143 @@
+package cleura
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+)
+
+// ShootEventType describes a change observed by WatchShootClusters.
+type ShootEventType string
+
+const (
+	ShootAdded              ShootEventType = "Added"
+	ShootRemoved            ShootEventType = "Removed"
+	ShootOperationStarted   ShootEventType = "OperationStarted"
+	ShootProgressChanged    ShootEventType = "ProgressChanged"
+	ShootOperationSucceeded ShootEventType = "OperationSucceeded"
+	ShootOperationFailed    ShootEventType = "OperationFailed"
+	ShootConditionChanged   ShootEventType = "ConditionChanged"
+	ShootHibernationChanged ShootEventType = "HibernationChanged"
+	// Listing shoot clusters failed, watch continues with the next poll.
+	ShootWatchError ShootEventType = "Error"
+)
+
+// ShootEvent is a change of a shoot cluster between two successive polls.
+type ShootEvent struct {
+	Type ShootEventType
+	Name string
+	Time time.Time
+	// Current shoot state. Last known state for ShootRemoved.
+	Shoot *ShootClusterResponse
+	// Previous shoot state. Nil for ShootAdded.
+	Previous *ShootClusterResponse
+	// Changed condition for ShootConditionChanged.
+	Condition *Condition
+	// Error for ShootWatchError.
+	Err error
+}
+
+// WatchShootClusters polls shoot clusters in the given project and region every interval and
+// emits events describing changes between successive snapshots. Shoots present in the first
+// snapshot are reported as ShootAdded. Channel is closed when ctx is done.
+func (c *Client) WatchShootClusters(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, interval time.Duration) <-chan ShootEvent {
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+	events := make(chan ShootEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		previous := map[string]ShootClusterResponse{}
+		for {
+			var batch []ShootEvent
+			shoots, err := c.ListShootClusters(ctx, gardenDomain, clusterRegion, clusterProject)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				batch = []ShootEvent{{Type: ShootWatchError, Time: time.Now(), Err: err}}
+			} else {
+				current := make(map[string]ShootClusterResponse, len(shoots))
+				for _, shoot := range shoots {
+					current[shoot.Metadata.Name] = shoot
+				}
+				batch = DiffShootClusters(previous, current, time.Now())
+				previous = current
+			}
+			for _, event := range batch {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events
+}
+
+// DiffShootClusters returns events describing changes between two snapshots of shoot clusters keyed by name.
+func DiffShootClusters(previous, current map[string]ShootClusterResponse, now time.Time) []ShootEvent {
+	var events []ShootEvent
+	for _, name := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := current[name]; !ok {
+			prev := previous[name]
+			events = append(events, ShootEvent{Type: ShootRemoved, Name: name, Time: now, Shoot: &prev})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		curr := current[name]
+		prev, ok := previous[name]
+		if !ok {
+			events = append(events, ShootEvent{Type: ShootAdded, Name: name, Time: now, Shoot: &curr})
+			continue
+		}
+		newEvent := func(eventType ShootEventType) ShootEvent {
+			return ShootEvent{Type: eventType, Name: name, Time: now, Shoot: &curr, Previous: &prev}
+		}
+		prevOp, currOp := prev.Status.LastOperation, curr.Status.LastOperation
+		switch {
+		case currOp.Type != prevOp.Type || (isFinishedOperationState(prevOp.State) && !isFinishedOperationState(currOp.State)):
+			events = append(events, newEvent(ShootOperationStarted))
+			if isFinishedOperationState(currOp.State) {
+				// Operation started and finished between polls
+				events = append(events, operationFinishedEvent(newEvent, currOp.State))
+			}
+		case currOp.State != prevOp.State && isFinishedOperationState(currOp.State):
+			events = append(events, operationFinishedEvent(newEvent, currOp.State))
+		case currOp.Progress != prevOp.Progress || currOp.State != prevOp.State:
+			events = append(events, newEvent(ShootProgressChanged))
+		}
+		if curr.Status.Hibernated != prev.Status.Hibernated {
+			events = append(events, newEvent(ShootHibernationChanged))
+		}
+		prevConditions := make(map[string]Condition, len(prev.Status.Conditions))
+		for _, condition := range prev.Status.Conditions {
+			prevConditions[condition.Type] = condition
+		}
+		for _, condition := range curr.Status.Conditions {
+			if prevCondition, ok := prevConditions[condition.Type]; ok && prevCondition.Status == condition.Status {
+				continue
+			}
+			event := newEvent(ShootConditionChanged)
+			event.Condition = &condition
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func operationFinishedEvent(newEvent func(ShootEventType) ShootEvent, state string) ShootEvent {
+	if state == OperationStateSucceeded {
+		return newEvent(ShootOperationSucceeded)
+	}
+	return newEvent(ShootOperationFailed)
+}
@@ -0,0 +1,165 @@
+package cleura_test
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+func testShoot(name, opType, opState string, progress int16, conditions ...cleura.Condition) cleura.ShootClusterResponse {
+	var shoot cleura.ShootClusterResponse
+	shoot.Metadata.Name = name
+	shoot.Status.LastOperation = cleura.LastOperationDetails{Type: opType, State: opState, Progress: progress}
+	shoot.Status.Conditions = conditions
+	return shoot
+}
+
+func hibernated(shoot cleura.ShootClusterResponse) cleura.ShootClusterResponse {
+	shoot.Status.Hibernated = true
+	return shoot
+}
+
+func TestDiffShootClusters(t *testing.T) {
+	ready := cleura.Condition{Type: "EveryNodeReady", Status: "True"}
+	notReady := cleura.Condition{Type: "EveryNodeReady", Status: "False"}
+	succeeded := testShoot("a", "Create", cleura.OperationStateSucceeded, 100, ready)
+	tests := []struct {
+		name     string
+		previous []cleura.ShootClusterResponse
+		current  []cleura.ShootClusterResponse
+		want     []cleura.ShootEventType
+	}{
+		{name: "no change", previous: []cleura.ShootClusterResponse{succeeded}, current: []cleura.ShootClusterResponse{succeeded}},
+		{name: "added", current: []cleura.ShootClusterResponse{succeeded}, want: []cleura.ShootEventType{cleura.ShootAdded}},
+		{name: "removed", previous: []cleura.ShootClusterResponse{succeeded}, want: []cleura.ShootEventType{cleura.ShootRemoved}},
+		{
+			name:     "operation started",
+			previous: []cleura.ShootClusterResponse{succeeded},
+			current:  []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateProcessing, 10, ready)},
+			want:     []cleura.ShootEventType{cleura.ShootOperationStarted},
+		},
+		{
+			name:     "same operation type started again",
+			previous: []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateSucceeded, 100, ready)},
+			current:  []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateProcessing, 10, ready)},
+			want:     []cleura.ShootEventType{cleura.ShootOperationStarted},
+		},
+		{
+			name:     "progress changed",
+			previous: []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateProcessing, 10, ready)},
+			current:  []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateProcessing, 50, ready)},
+			want:     []cleura.ShootEventType{cleura.ShootProgressChanged},
+		},
+		{
+			name:     "operation succeeded",
+			previous: []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateProcessing, 50, ready)},
+			current:  []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateSucceeded, 100, ready)},
+			want:     []cleura.ShootEventType{cleura.ShootOperationSucceeded},
+		},
+		{
+			name:     "operation failed",
+			previous: []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateProcessing, 50, ready)},
+			current:  []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateFailed, 50, ready)},
+			want:     []cleura.ShootEventType{cleura.ShootOperationFailed},
+		},
+		{
+			name:     "operation started and finished between polls",
+			previous: []cleura.ShootClusterResponse{succeeded},
+			current:  []cleura.ShootClusterResponse{testShoot("a", "Reconcile", cleura.OperationStateSucceeded, 100, ready)},
+			want:     []cleura.ShootEventType{cleura.ShootOperationStarted, cleura.ShootOperationSucceeded},
+		},
+		{
+			name:     "hibernation and condition changed",
+			previous: []cleura.ShootClusterResponse{succeeded},
+			current:  []cleura.ShootClusterResponse{hibernated(testShoot("a", "Create", cleura.OperationStateSucceeded, 100, notReady))},
+			want:     []cleura.ShootEventType{cleura.ShootHibernationChanged, cleura.ShootConditionChanged},
+		},
+		{
+			name:     "removed reported before added",
+			previous: []cleura.ShootClusterResponse{testShoot("b", "Create", cleura.OperationStateSucceeded, 100)},
+			current:  []cleura.ShootClusterResponse{succeeded},
+			want:     []cleura.ShootEventType{cleura.ShootRemoved, cleura.ShootAdded},
+		},
+	}
+	byName := func(shoots []cleura.ShootClusterResponse) map[string]cleura.ShootClusterResponse {
+		m := map[string]cleura.ShootClusterResponse{}
+		for _, s := range shoots {
+			m[s.Metadata.Name] = s
+		}
+		return m
+	}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := cleura.DiffShootClusters(byName(tt.previous), byName(tt.current), now)
+			var got []cleura.ShootEventType
+			for _, event := range events {
+				got = append(got, event.Type)
+				if !event.Time.Equal(now) {
+					t.Errorf("%s event time = %s, want %s", event.Type, event.Time, now)
+				}
+				if event.Shoot == nil {
+					t.Errorf("%s event has no shoot", event.Type)
+				}
+				if (event.Previous == nil) != (event.Type == cleura.ShootAdded || event.Type == cleura.ShootRemoved) {
+					t.Errorf("%s event previous = %v", event.Type, event.Previous)
+				}
+				if (event.Condition != nil) != (event.Type == cleura.ShootConditionChanged) {
+					t.Errorf("%s event condition = %v", event.Type, event.Condition)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchShootClusters(t *testing.T) {
+	var clock atomic.Int64
+	clock.Store(time.Now().UnixNano())
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	srv.API.SetClock(func() time.Time { return time.Unix(0, clock.Load()) })
+	srv.API.SetOperationDuration(time.Minute)
+	srv.API.AddShoot(cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID,
+		testShoot("demo", "Create", cleura.OperationStateSucceeded, 100))
+	client, err := srv.CleuraClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events := client.WatchShootClusters(ctx, cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, 10*time.Millisecond)
+	next := func(want cleura.ShootEventType) {
+		t.Helper()
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("watch closed, want %s event", want)
+			}
+			if event.Type != want || event.Name != "demo" {
+				t.Fatalf("event = %s %s (error %v), want %s demo", event.Type, event.Name, event.Err, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("no %s event", want)
+		}
+	}
+	next(cleura.ShootAdded)
+	if err := client.HibernateCluster(ctx, cleuratest.DefaultGardenDomain, cleuratest.DefaultRegion, cleuratest.DefaultProjectID, "demo"); err != nil {
+		t.Fatal(err)
+	}
+	next(cleura.ShootOperationStarted)
+	clock.Add(int64(2 * time.Minute))
+	next(cleura.ShootOperationSucceeded)
+	next(cleura.ShootHibernationChanged)
+	next(cleura.ShootConditionChanged)
+	cancel()
+	for range events {
+	}
+}
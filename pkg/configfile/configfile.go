@@ -31,6 +31,7 @@ type profile struct {
 	DefaultProjectID string `yaml:"project-id,omitempty"`
 	ApiUrl           string `yaml:"api-url,omitempty"`
 	GardenerDomain   string `yaml:"gardener-domain,omitempty"`
+	MaxRPS           string `yaml:"max-rps,omitempty"`
 }
 
 // Validate configuration file for active profile and profile data.
@@ -0,0 +1,181 @@
+package manifest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+// Location returns location of the shoot cluster. Region, project and gardener domain not set
+// in metadata are taken from `defaults`.
+func (s *Shoot) Location(defaults cleura.ShootLocation) (cleura.ShootLocation, error) {
+	loc := defaults
+	if s.Metadata.Region != "" {
+		loc.Region = s.Metadata.Region
+	}
+	if s.Metadata.Project != "" {
+		loc.ProjectID = s.Metadata.Project
+	}
+	if s.Metadata.GardenDomain != "" {
+		loc.GardenDomain = s.Metadata.GardenDomain
+	}
+	if loc.GardenDomain == "" {
+		loc.GardenDomain = cleura.DefaultGardenDomain
+	}
+	if loc.Region == "" || loc.ProjectID == "" {
+		return loc, fmt.Errorf("%s: region and project must be set in metadata or given as defaults", s)
+	}
+	return loc, nil
+}
+
+// ResolveVersions replaces Kubernetes and machine image version queries such as `latest-supported`
+// or `1.31` with versions from the cloud profile not expired at `now`. See cleura.ResolveVersion.
+func (s *Shoot) ResolveVersions(profile *cleura.CloudProfile, now time.Time) error {
+	version, err := profile.Spec.ResolveKubernetesVersion(s.Spec.Kubernetes.Version, now)
+	if err != nil {
+		return fmt.Errorf("%s: spec.kubernetes.version: %w", s, err)
+	}
+	s.Spec.Kubernetes.Version = version
+	for i := range s.Spec.Workers {
+		image := &s.Spec.Workers[i].Machine.Image
+		version, err := profile.Spec.ResolveMachineImageVersion(image.Name, image.Version, now)
+		if err != nil {
+			return fmt.Errorf("%s: spec.workers[%d].machine.image.version: %w", s, i, err)
+		}
+		image.Version = version
+	}
+	return nil
+}
+
+// Builder returns builder of a request creating the shoot cluster.
+func (s *Shoot) Builder() *cleura.ShootClusterBuilder {
+	spec := s.Spec
+	b := cleura.NewShootClusterBuilder(s.Metadata.Name).
+		WithKubernetes(spec.Kubernetes.Version).
+		WithPurpose(spec.Purpose)
+	if highlyAvailable(spec) {
+		b.WithHAControlPlane()
+	}
+	for _, w := range spec.Workers {
+		b.AddWorkerGroup(w.Builder())
+	}
+	if spec.Hibernation != nil {
+		for _, schedule := range spec.Hibernation.Schedules {
+			b.WithHibernationSchedule(schedule.Start, schedule.End)
+		}
+	}
+	if spec.Maintenance != nil {
+		if tw := spec.Maintenance.TimeWindow; tw != nil {
+			b.WithMaintenanceWindow(tw.Begin, tw.End)
+		}
+		if au := spec.Maintenance.AutoUpdate; au != nil {
+			b.WithAutoUpdate(au.KubernetesVersion, au.MachineImageVersion)
+		}
+	}
+	if n := spec.Networking; n != nil {
+		if n.NetworkID != "" || n.RouterID != "" {
+			b.WithExistingNetwork(n.NetworkID, n.RouterID)
+		}
+		if n.WorkersCIDR != "" {
+			b.WithWorkersCIDR(n.WorkersCIDR)
+		}
+	}
+	return b
+}
+
+// Builder returns builder of a request creating or updating the worker group.
+func (w Worker) Builder() *cleura.WorkerGroupBuilder {
+	b := cleura.NewWorkerGroupBuilder(w.Name).
+		WithMachine(w.Machine.Type, w.Machine.Image.Name, w.Machine.Image.Version).
+		WithAutoscaling(w.Minimum, w.Maximum).
+		WithMaxSurge(w.MaxSurge).
+		WithZones(w.Zones...)
+	if w.Volume != nil {
+		b.WithVolumeSize(w.Volume.Size).WithVolumeType(w.Volume.Type)
+	}
+	for _, key := range sortedKeys(w.Labels) {
+		b.WithLabel(key, w.Labels[key])
+	}
+	for _, key := range sortedKeys(w.Annotations) {
+		b.WithAnnotation(key, w.Annotations[key])
+	}
+	for _, t := range w.Taints {
+		b.WithTaint(t.Key, t.Value, t.Effect)
+	}
+	return b
+}
+
+// FromShootCluster converts live shoot cluster into a manifest with every field set.
+func FromShootCluster(live *cleura.ShootClusterResponse, loc cleura.ShootLocation) *Shoot {
+	spec := live.Spec
+	s := &Shoot{
+		APIVersion: APIVersion,
+		Kind:       KindShoot,
+		Metadata: Metadata{
+			Name:         live.Metadata.Name,
+			Region:       loc.Region,
+			Project:      loc.ProjectID,
+			GardenDomain: loc.GardenDomain,
+		},
+		Spec: Spec{
+			Purpose:    spec.Purpose,
+			Kubernetes: Kubernetes{Version: spec.Kubernetes.Version},
+			Workers:    []Worker{},
+			Hibernation: &Hibernation{
+				Schedules: []HibernationSchedule{},
+			},
+		},
+	}
+	if ft := spec.ControlPlane.HighAvailability.FailureTolerance.Type; ft != "" {
+		s.Spec.ControlPlane = &ControlPlane{HighAvailability: &HighAvailability{FailureTolerance: ft}}
+	}
+	for _, w := range spec.Provider.Workers {
+		s.Spec.Workers = append(s.Spec.Workers, workerFromResponse(w))
+	}
+	for _, schedule := range spec.Hibernation.HibernationResponseSchedules {
+		s.Spec.Hibernation.Schedules = append(s.Spec.Hibernation.Schedules, HibernationSchedule{Start: schedule.Start, End: schedule.End})
+	}
+	if spec.Maintenance.TimeWindow != nil || spec.Maintenance.AutoUpdate != nil {
+		s.Spec.Maintenance = &Maintenance{}
+		if tw := spec.Maintenance.TimeWindow; tw != nil {
+			s.Spec.Maintenance.TimeWindow = &TimeWindow{Begin: tw.Begin, End: tw.End}
+		}
+		if au := spec.Maintenance.AutoUpdate; au != nil {
+			s.Spec.Maintenance.AutoUpdate = &AutoUpdate{KubernetesVersion: au.KubernetesVersion, MachineImageVersion: au.MachineImageVersion}
+		}
+	}
+	if n := spec.Provider.InfrastructureConfig.Networks; n != nil {
+		s.Spec.Networking = &Networking{NetworkID: n.Id, RouterID: n.Router.Id, WorkersCIDR: n.WorkersCIDR}
+	}
+	return s
+}
+
+// Convert worker group of a live shoot cluster. Labels and annotations are maps in responses
+// but lists of key value pairs in requests.
+func workerFromResponse(w cleura.WorkerUpdateResponse) Worker {
+	worker := Worker{
+		Name:        w.Name,
+		Machine:     Machine{Type: w.Machine.Type, Image: Image{Name: w.Machine.Image.Name, Version: w.Machine.Image.Version}},
+		Minimum:     w.Minimum,
+		Maximum:     w.Maximum,
+		MaxSurge:    w.MaxSurge,
+		Zones:       w.Zones,
+		Labels:      w.Labels,
+		Annotations: w.Annotations,
+		Taints:      []Taint{},
+	}
+	if worker.Labels == nil {
+		worker.Labels = map[string]string{}
+	}
+	if worker.Annotations == nil {
+		worker.Annotations = map[string]string{}
+	}
+	if w.Volume.Size != "" || w.Volume.Type != "" {
+		worker.Volume = &Volume{Size: w.Volume.Size, Type: w.Volume.Type}
+	}
+	for _, t := range w.Taints {
+		worker.Taints = append(worker.Taints, Taint{Key: t.Key, Value: t.Value, Effect: t.Effect})
+	}
+	return worker
+}
@@ -0,0 +1,71 @@
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"gopkg.in/yaml.v2"
+)
+
+// Export converts a live shoot cluster into a manifest that applies without changes.
+// Status and fields left empty by the API are dropped.
+func Export(live *cleura.ShootClusterResponse, loc cleura.ShootLocation) *Shoot {
+	s := FromShootCluster(live, loc)
+	for i := range s.Spec.Workers {
+		w := &s.Spec.Workers[i]
+		if len(w.Labels) == 0 {
+			w.Labels = nil
+		}
+		if len(w.Annotations) == 0 {
+			w.Annotations = nil
+		}
+		if len(w.Taints) == 0 {
+			w.Taints = nil
+		}
+	}
+	if n := s.Spec.Networking; n != nil && *n == (Networking{}) {
+		s.Spec.Networking = nil
+	}
+	return s
+}
+
+// Encode writes manifests as a multi-document YAML stream.
+func Encode(w io.Writer, shoots ...*Shoot) error {
+	for i, shoot := range shoots {
+		data, err := yaml.Marshal(shoot)
+		if err != nil {
+			return fmt.Errorf("%s: %w", shoot, err)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile writes the manifest to <dir>/<name>.yaml and returns path of the file.
+// Existing files are only replaced if overwrite is set.
+func WriteFile(dir string, shoot *Shoot, overwrite bool) (string, error) {
+	path := filepath.Join(dir, shoot.Metadata.Name+".yaml")
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return "", err
+	}
+	if err := Encode(f, shoot); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
@@ -0,0 +1,172 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+// Exported manifests plan no changes against the shoot clusters they were exported from.
+func TestExportRoundTrip(t *testing.T) {
+	minimal := func() cleura.ShootClusterResponse {
+		var live cleura.ShootClusterResponse
+		live.Metadata.Name = "demo"
+		live.Spec.Region = testLocation.Region
+		live.Spec.Kubernetes.Version = "1.31.4"
+		live.Spec.Provider.Workers = []cleura.WorkerUpdateResponse{{
+			Name:    "sys",
+			Minimum: 1,
+			Maximum: 3,
+			Machine: cleura.MachineDetails{Type: "b.2c4gb", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+		}}
+		return live
+	}
+	tests := []struct {
+		name string
+		live func() cleura.ShootClusterResponse
+	}{
+		{name: "minimal", live: minimal},
+		{name: "every worker group field", live: func() cleura.ShootClusterResponse { return *testLiveShoot() }},
+		{
+			name: "empty labels, annotations and taints",
+			live: func() cleura.ShootClusterResponse {
+				live := minimal()
+				live.Spec.Provider.Workers[0].Labels = map[string]string{}
+				live.Spec.Provider.Workers[0].Annotations = map[string]string{}
+				live.Spec.Provider.Workers[0].Taints = []cleura.Taint{}
+				return live
+			},
+		},
+		{
+			name: "highly available control plane",
+			live: func() cleura.ShootClusterResponse {
+				live := minimal()
+				live.Spec.ControlPlane.HighAvailability.FailureTolerance.Type = cleura.FailureToleranceNode
+				return live
+			},
+		},
+		{
+			name: "hibernation and maintenance",
+			live: func() cleura.ShootClusterResponse {
+				live := minimal()
+				live.Spec.Purpose = "production"
+				live.Spec.Hibernation.HibernationResponseSchedules = []cleura.HibernationResponseSchedule{
+					{Start: "00 18 * * 1,2,3,4,5", End: "00 08 * * 1,2,3,4,5", Location: "Etc/UTC"},
+					{Start: "00 20 * * 6"},
+				}
+				live.Spec.Maintenance = cleura.MaintenanceDetails{
+					AutoUpdate: &cleura.AutoUpdateDetails{KubernetesVersion: false, MachineImageVersion: true},
+					TimeWindow: &cleura.TimeWindowDetails{Begin: "220000+0100", End: "230000+0100"},
+				}
+				return live
+			},
+		},
+		{
+			name: "existing network",
+			live: func() cleura.ShootClusterResponse {
+				live := minimal()
+				live.Spec.Provider.InfrastructureConfig.Networks = &cleura.WorkerNetwork{Id: "net-1", Router: cleura.Router{Id: "router-1"}, WorkersCIDR: "10.250.0.0/16"}
+				return live
+			},
+		},
+		{
+			name: "expired versions",
+			live: func() cleura.ShootClusterResponse {
+				live := minimal()
+				live.Spec.Kubernetes.Version = "1.28.8"
+				live.Spec.Provider.Workers[0].Machine.Image.Version = "1312.3.0"
+				return live
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := cleuratest.NewServer(nil)
+			defer srv.Close()
+			srv.API.AddShoot(testLocation.GardenDomain, testLocation.Region, testLocation.ProjectID, tt.live())
+			client, err := srv.CleuraClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			live, err := client.GetShootCluster(ctx, testLocation.GardenDomain, "demo", testLocation.Region, testLocation.ProjectID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := Encode(&buf, Export(live, testLocation)); err != nil {
+				t.Fatal(err)
+			}
+			shoots, err := Decode(&buf, "export")
+			if err != nil {
+				t.Fatalf("decoding exported manifest: %v\n%s", err, buf.String())
+			}
+			loc, err := shoots[0].Location(cleura.ShootLocation{GardenDomain: testLocation.GardenDomain})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc != testLocation {
+				t.Errorf("location = %+v, want %+v", loc, testLocation)
+			}
+			plan, err := GetPlan(ctx, client, shoots[0], loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plan.Drift() {
+				t.Errorf("plan of exported manifest = %v, warnings %v, want no changes\n%s", plan.Actions, plan.Warnings, buf.String())
+			}
+			if err := plan.Validate(nil); err != nil {
+				t.Errorf("validation of exported manifest: %v", err)
+			}
+		})
+	}
+}
+
+// Shoot clusters created from a manifest export to a manifest planning no changes.
+func TestExportAfterApply(t *testing.T) {
+	api := cleuratest.NewAPI()
+	api.SetOperationDuration(0)
+	srv := cleuratest.NewServer(api)
+	defer srv.Close()
+	client, err := srv.CleuraClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	desired := decodeTestShoot(t, testManifestHeader+`      maximum: 3
+      labels:
+        team: ops
+      taints:
+        - key: dedicated
+          value: ops
+          effect: NoSchedule
+  maintenance:
+    timeWindow:
+      begin: 220000+0100
+      end: 230000+0100
+`)
+	plan, err := GetPlan(ctx, client, desired, testLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(ctx, client, plan, ApplyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	live, err := client.GetShootCluster(ctx, testLocation.GardenDomain, "demo", testLocation.Region, testLocation.ProjectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported := Export(live, testLocation)
+	for _, shoot := range []*Shoot{desired, exported} {
+		replan, err := NewPlan(shoot, testLocation, live)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if replan.Drift() {
+			t.Errorf("plan of %s = %v, warnings %v, want no changes", shoot, replan.Actions, replan.Warnings)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Decode reads every document of a possibly multi-document YAML stream. Unknown fields are
+// rejected. `source` names the stream in errors and Shoot.Source.
+func Decode(r io.Reader, source string) ([]*Shoot, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.SetStrict(true)
+	var shoots []*Shoot
+	for i := 1; ; i++ {
+		var shoot Shoot
+		err := decoder.Decode(&shoot)
+		if errors.Is(err, io.EOF) {
+			return shoots, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: document %d: %w", source, i, err)
+		}
+		// Skip empty documents, e.g. trailing `---`
+		if reflect.DeepEqual(shoot, Shoot{}) {
+			continue
+		}
+		shoot.Source = source
+		if err := shoot.check(); err != nil {
+			return nil, fmt.Errorf("%s: document %d: %w", source, i, err)
+		}
+		shoots = append(shoots, &shoot)
+	}
+}
+
+// LoadWorkers reads a YAML list of worker groups in the format of spec.workers of shoot manifests.
+// Unknown fields are rejected. Path "-" reads standard input.
+func LoadWorkers(path string) ([]Worker, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var workers []Worker
+	if err := yaml.UnmarshalStrict(data, &workers); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := checkWorkers("workers", workers); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return workers, nil
+}
+
+// Load reads manifests from files and directories. Directories are searched recursively for
+// .yaml and .yml files, in lexical order. Path "-" reads standard input.
+func Load(paths ...string) ([]*Shoot, error) {
+	var shoots []*Shoot
+	for _, path := range paths {
+		files, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			var data []byte
+			if file == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(file)
+			}
+			if err != nil {
+				return nil, err
+			}
+			decoded, err := Decode(bytes.NewReader(data), file)
+			if err != nil {
+				return nil, err
+			}
+			shoots = append(shoots, decoded...)
+		}
+	}
+	return shoots, nil
+}
+
+// List manifest files under path.
+func manifestFiles(path string) ([]string, error) {
+	if path == "-" {
+		return []string{path}, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && slices.Contains([]string{".yaml", ".yml"}, filepath.Ext(p)) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,194 @@
+// Package manifest implements declarative shoot cluster manifests and planning of
+// API calls needed to bring a live shoot cluster in line with its manifest.
+package manifest
+
+import (
+	"fmt"
+)
+
+// APIVersion - Current version of the manifest format.
+const APIVersion = "cleura.cloud/v1alpha1"
+
+// KindShoot - Kind of shoot cluster manifests.
+const KindShoot = "Shoot"
+
+// Shoot is a manifest describing desired state of a shoot cluster.
+//
+//	apiVersion: cleura.cloud/v1alpha1
+//	kind: Shoot
+//	metadata:
+//	  name: demo
+//	  region: sto2
+//	  project: 0123456789abcdef
+//	spec:
+//	  kubernetes:
+//	    version: 1.31.4
+//	  workers:
+//	    - name: sys
+//	      machine:
+//	        type: b.2c4gb
+//	        image:
+//	          name: gardenlinux
+//	          version: 1592.4.0
+//	      minimum: 2
+//	      maximum: 3
+type Shoot struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+	Spec       Spec     `yaml:"spec"`
+
+	// File the manifest was loaded from, if any.
+	Source string `yaml:"-"`
+}
+
+// Metadata identifies shoot cluster. Location fields left empty are taken from defaults at apply time.
+type Metadata struct {
+	Name         string `yaml:"name"`
+	Region       string `yaml:"region,omitempty"`
+	Project      string `yaml:"project,omitempty"`
+	GardenDomain string `yaml:"gardenDomain,omitempty"`
+}
+
+// Spec is desired state of a shoot cluster. Optional fields left unset are not managed,
+// i.e. live values are kept as they are.
+type Spec struct {
+	Purpose      string        `yaml:"purpose,omitempty"`
+	Kubernetes   Kubernetes    `yaml:"kubernetes"`
+	ControlPlane *ControlPlane `yaml:"controlPlane,omitempty"`
+	Workers      []Worker      `yaml:"workers"`
+	Hibernation  *Hibernation  `yaml:"hibernation,omitempty"`
+	Maintenance  *Maintenance  `yaml:"maintenance,omitempty"`
+	Networking   *Networking   `yaml:"networking,omitempty"`
+}
+
+// Kubernetes settings. Version may also be a query resolved by cleura.ResolveVersion. It is required
+// to create a shoot cluster; if omitted afterwards the live version is kept.
+type Kubernetes struct {
+	Version string `yaml:"version"`
+}
+
+// ControlPlane settings.
+type ControlPlane struct {
+	HighAvailability *HighAvailability `yaml:"highAvailability,omitempty"`
+}
+
+// HighAvailability of the control plane. Can not be disabled once enabled.
+type HighAvailability struct {
+	// Failure tolerance type reported by the API: node or zone. It is chosen by the API when high
+	// availability is enabled and can not be changed, so it is only compared with the live value.
+	FailureTolerance string `yaml:"failureTolerance,omitempty"`
+}
+
+// Worker group. Optional fields left unset are not managed.
+type Worker struct {
+	Name        string            `yaml:"name"`
+	Machine     Machine           `yaml:"machine"`
+	Minimum     int16             `yaml:"minimum"`
+	Maximum     int16             `yaml:"maximum"`
+	MaxSurge    int16             `yaml:"maxSurge,omitempty"`
+	Volume      *Volume           `yaml:"volume,omitempty"`
+	Zones       []string          `yaml:"zones,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Taints      []Taint           `yaml:"taints,omitempty"`
+}
+
+// Machine type and image of worker nodes.
+type Machine struct {
+	Type  string `yaml:"type"`
+	Image Image  `yaml:"image"`
+}
+
+// Image of worker nodes. Version may also be a query resolved by cleura.ResolveVersion.
+type Image struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// Volume of worker nodes. Type must be available in zones of the worker group.
+type Volume struct {
+	Size string `yaml:"size"`
+	Type string `yaml:"type,omitempty"`
+}
+
+// Taint of worker nodes.
+type Taint struct {
+	Key    string `yaml:"key"`
+	Value  string `yaml:"value,omitempty"`
+	Effect string `yaml:"effect"`
+}
+
+// Hibernation schedules. Empty list removes all schedules.
+type Hibernation struct {
+	Schedules []HibernationSchedule `yaml:"schedules"`
+}
+
+// HibernationSchedule with start and end in cron format.
+type HibernationSchedule struct {
+	Start string `yaml:"start,omitempty"`
+	End   string `yaml:"end,omitempty"`
+}
+
+// Maintenance settings.
+type Maintenance struct {
+	TimeWindow *TimeWindow `yaml:"timeWindow,omitempty"`
+	AutoUpdate *AutoUpdate `yaml:"autoUpdate,omitempty"`
+}
+
+// TimeWindow with begin and end formatted as HHMMSS+ZZZZ.
+type TimeWindow struct {
+	Begin string `yaml:"begin"`
+	End   string `yaml:"end"`
+}
+
+// AutoUpdate toggles automatic updates during maintenance.
+type AutoUpdate struct {
+	KubernetesVersion   bool `yaml:"kubernetesVersion"`
+	MachineImageVersion bool `yaml:"machineImageVersion"`
+}
+
+// Networking settings. Can only be set when the shoot cluster is created.
+type Networking struct {
+	NetworkID   string `yaml:"networkId,omitempty"`
+	RouterID    string `yaml:"routerId,omitempty"`
+	WorkersCIDR string `yaml:"workersCIDR,omitempty"`
+}
+
+// Name of the manifest for messages, including its source file if known.
+func (s *Shoot) String() string {
+	if s.Source != "" {
+		return fmt.Sprintf("shoot `%s` (%s)", s.Metadata.Name, s.Source)
+	}
+	return fmt.Sprintf("shoot `%s`", s.Metadata.Name)
+}
+
+// Check manifest header and fields required to identify the shoot and its worker groups.
+// Spec values are validated by cleura.ValidateShootClusterRequest.
+func (s *Shoot) check() error {
+	if s.APIVersion != APIVersion {
+		return fmt.Errorf("unsupported apiVersion %q, expected %q", s.APIVersion, APIVersion)
+	}
+	if s.Kind != KindShoot {
+		return fmt.Errorf("unsupported kind %q, expected %q", s.Kind, KindShoot)
+	}
+	if s.Metadata.Name == "" {
+		return fmt.Errorf("metadata.name must be set")
+	}
+	return checkWorkers("spec.workers", s.Spec.Workers)
+}
+
+// Check that worker groups are named and names are unique. Field is path to the list in messages.
+func checkWorkers(field string, workers []Worker) error {
+	names := make(map[string]bool)
+	for i, w := range workers {
+		if w.Name == "" {
+			return fmt.Errorf("%s[%d].name must be set", field, i)
+		}
+		if names[w.Name] {
+			return fmt.Errorf("%s[%d]: duplicate worker group name %s", field, i, w.Name)
+		}
+		names[w.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,540 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+// ActionType names the API call made by an action.
+type ActionType string
+
+const (
+	ActionCreate            ActionType = "CreateShootCluster"
+	ActionUpdate            ActionType = "UpdateShootCluster"
+	ActionEnableHA          ActionType = "EnableHaControlPlane"
+	ActionAddWorkerGroup    ActionType = "AddWorkerGroup"
+	ActionUpdateWorkerGroup ActionType = "UpdateWorkerGroup"
+	ActionDeleteWorkerGroup ActionType = "DeleteWorkerGroup"
+)
+
+// Separates list items in change values.
+const changeListSeparator = ","
+
+// Change of a single field. From is empty for added fields, To is empty for removed fields.
+type Change struct {
+	Field string
+	From  string
+	To    string
+}
+
+// Action is a single API call of a plan.
+type Action struct {
+	Type ActionType
+	// Worker group the action applies to, if any.
+	WorkerGroup string
+	Changes     []Change
+
+	shootRequest  cleura.ShootClusterRequest
+	workerRequest cleura.WorkerGroupRequest
+}
+
+func (a Action) String() string {
+	if a.WorkerGroup != "" {
+		return fmt.Sprintf("%s %s", a.Type, a.WorkerGroup)
+	}
+	return string(a.Type)
+}
+
+// Plan lists API calls bringing live shoot cluster in line with its manifest.
+type Plan struct {
+	Shoot    *Shoot
+	Location cleura.ShootLocation
+	// Live shoot cluster, nil if it does not exist yet.
+	Live    *cleura.ShootClusterResponse
+	Actions []Action
+	// Differences that can not be applied, e.g. networking of an existing shoot cluster.
+	Warnings []string
+}
+
+// Empty reports whether live shoot cluster matches the manifest.
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// Drift reports whether live shoot cluster differs from the manifest, including differences
+// that can not be applied.
+func (p *Plan) Drift() bool {
+	return len(p.Actions) > 0 || len(p.Warnings) > 0
+}
+
+// Endpoint returns HTTP method and path of the API call made by the action.
+func (p *Plan) Endpoint(a Action) (string, string) {
+	base := fmt.Sprintf("/gardener/v1/%s/shoot/%s/%s", p.Location.GardenDomain, p.Location.Region, p.Location.ProjectID)
+	shoot := base + "/" + p.Shoot.Metadata.Name
+	switch a.Type {
+	case ActionCreate:
+		return "POST", base
+	case ActionUpdate:
+		return "PUT", shoot
+	case ActionEnableHA:
+		return "POST", shoot + "/enable-ha-control-plane"
+	case ActionAddWorkerGroup:
+		return "POST", shoot + "/worker"
+	case ActionUpdateWorkerGroup:
+		return "PUT", shoot + "/worker/" + a.WorkerGroup
+	case ActionDeleteWorkerGroup:
+		return "DELETE", shoot + "/worker/" + a.WorkerGroup
+	}
+	return "", ""
+}
+
+// Validate checks requests of the plan against cloud profile, see cleura.ValidateShootClusterRequest.
+// Requests updating an existing shoot cluster carry live values of fields the plan does not change,
+// e.g. a Kubernetes version that has expired since, so only problems in changed fields are reported.
+// Returns *cleura.ValidationError listing all problems found, or nil.
+func (p *Plan) Validate(profile *cleura.CloudProfile) error {
+	var problems []cleura.ValidationProblem
+	for _, a := range p.Actions {
+		var err error
+		prefix := ""
+		switch a.Type {
+		case ActionCreate:
+			err = cleura.ValidateShootClusterRequest(a.shootRequest, profile, p.Location.Region)
+		case ActionUpdate:
+			err = cleura.ValidateShootClusterRequest(a.shootRequest, profile, p.Location.Region)
+			prefix = "shoot."
+		case ActionAddWorkerGroup, ActionUpdateWorkerGroup:
+			err = cleura.ValidateWorkerGroupRequest(a.workerRequest, profile, p.Location.Region)
+			prefix = "worker."
+		}
+		var ve *cleura.ValidationError
+		if !errors.As(err, &ve) {
+			continue
+		}
+		for _, problem := range ve.Problems {
+			if a.Type == ActionUpdate || a.Type == ActionUpdateWorkerGroup {
+				if !changedField(strings.TrimPrefix(problem.Field, prefix), a.Changes) {
+					continue
+				}
+			}
+			if a.WorkerGroup != "" {
+				problem.Field = fmt.Sprintf("workers[%s].%s", a.WorkerGroup, strings.TrimPrefix(problem.Field, prefix))
+			}
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &cleura.ValidationError{Problems: problems}
+}
+
+// Index of list items in request field paths, ex: "[0]" in "taints[0].effect".
+var fieldIndexPattern = regexp.MustCompile(`\[[0-9]+\]`)
+
+// Reports whether request `field` found invalid is one of the changed fields, or part of one.
+func changedField(field string, changes []Change) bool {
+	field = fieldIndexPattern.ReplaceAllString(field, "")
+	field = strings.Replace(field, "kubernetesVersion.", "kubernetes.", 1)
+	for _, c := range changes {
+		if c.Field == field || strings.HasPrefix(c.Field, field+".") || strings.HasPrefix(field, c.Field+".") {
+			return true
+		}
+		// Node counts are checked against each other
+		if (field == "minimum" || field == "maximum") && (c.Field == "minimum" || c.Field == "maximum") {
+			return true
+		}
+	}
+	return false
+}
+
+// NewPlan compares manifest with live shoot cluster and returns actions needed to apply it.
+// Live is nil if the shoot cluster does not exist. Worker groups missing from the manifest are deleted.
+func NewPlan(desired *Shoot, loc cleura.ShootLocation, live *cleura.ShootClusterResponse) (*Plan, error) {
+	plan := &Plan{Shoot: desired, Location: loc, Live: live}
+	if live == nil {
+		req, err := desired.Builder().Build()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", desired, err)
+		}
+		plan.Actions = append(plan.Actions, Action{
+			Type:         ActionCreate,
+			Changes:      specChanges(desired.Spec, Spec{}),
+			shootRequest: req,
+		})
+		plan.Warnings = append(plan.Warnings, immutableChanges(desired.Spec, Spec{})...)
+		return plan, nil
+	}
+	current := FromShootCluster(live, loc).Spec
+	if changes := shootChanges(desired.Spec, current); len(changes) > 0 {
+		plan.Actions = append(plan.Actions, Action{
+			Type:         ActionUpdate,
+			Changes:      changes,
+			shootRequest: updateRequest(desired.Spec),
+		})
+	}
+	plan.Warnings = append(plan.Warnings, immutableChanges(desired.Spec, current)...)
+	if highlyAvailable(desired.Spec) && !highlyAvailable(current) {
+		plan.Actions = append(plan.Actions, Action{
+			Type:    ActionEnableHA,
+			Changes: []Change{highAvailabilityChange},
+		})
+	}
+	// Add new worker groups before deleting old ones, so renaming a group never leaves the cluster without nodes
+	var adds, updates, deletes []Action
+	for _, w := range desired.Spec.Workers {
+		i := slices.IndexFunc(current.Workers, func(c Worker) bool { return c.Name == w.Name })
+		if i < 0 {
+			req, err := w.Builder().Build()
+			if err != nil {
+				return nil, fmt.Errorf("%s: worker group %s: %w", desired, w.Name, err)
+			}
+			adds = append(adds, Action{Type: ActionAddWorkerGroup, WorkerGroup: w.Name, Changes: workerChanges(w, Worker{}, false), workerRequest: req})
+			continue
+		}
+		// Update request replaces the whole worker group, keep live values of fields the manifest leaves out.
+		// Changes and request are both derived from the merged worker group, so the plan shows what is sent.
+		merged := mergeWorker(w, current.Workers[i])
+		if changes := workerChanges(merged, current.Workers[i], true); len(changes) > 0 {
+			req, err := merged.Builder().Build()
+			if err != nil {
+				return nil, fmt.Errorf("%s: worker group %s: %w", desired, w.Name, err)
+			}
+			updates = append(updates, Action{Type: ActionUpdateWorkerGroup, WorkerGroup: w.Name, Changes: changes, workerRequest: req})
+		}
+	}
+	for _, c := range current.Workers {
+		if !slices.ContainsFunc(desired.Spec.Workers, func(w Worker) bool { return w.Name == c.Name }) {
+			deletes = append(deletes, Action{Type: ActionDeleteWorkerGroup, WorkerGroup: c.Name, Changes: workerChanges(Worker{}, c, true)})
+		}
+	}
+	plan.Actions = append(plan.Actions, adds...)
+	plan.Actions = append(plan.Actions, updates...)
+	plan.Actions = append(plan.Actions, deletes...)
+	return plan, nil
+}
+
+// Request updating fields managed by the manifest.
+func updateRequest(spec Spec) cleura.ShootClusterRequest {
+	req := cleura.ShootClusterRequest{
+		Shoot: cleura.ShootClusterRequestConfig{
+			Purpose: spec.Purpose,
+		},
+	}
+	if spec.Kubernetes.Version != "" {
+		req.Shoot.KubernetesVersion = &cleura.K8sVersion{Version: spec.Kubernetes.Version}
+	}
+	if spec.Hibernation != nil {
+		req.Shoot.Hibernation = &cleura.HibernationSchedules{HibernationSchedules: []cleura.HibernationSchedule{}}
+		for _, schedule := range spec.Hibernation.Schedules {
+			req.Shoot.Hibernation.HibernationSchedules = append(req.Shoot.Hibernation.HibernationSchedules, cleura.HibernationSchedule{Start: schedule.Start, End: schedule.End})
+		}
+	}
+	if spec.Maintenance != nil {
+		req.Shoot.Maintenance = &cleura.MaintenanceDetails{}
+		if tw := spec.Maintenance.TimeWindow; tw != nil {
+			req.Shoot.Maintenance.TimeWindow = &cleura.TimeWindowDetails{Begin: tw.Begin, End: tw.End}
+		}
+		if au := spec.Maintenance.AutoUpdate; au != nil {
+			req.Shoot.Maintenance.AutoUpdate = &cleura.AutoUpdateDetails{KubernetesVersion: au.KubernetesVersion, MachineImageVersion: au.MachineImageVersion}
+		}
+	}
+	return req
+}
+
+// Changes of every field set in desired spec, used to describe creation of a shoot cluster.
+func specChanges(desired, current Spec) []Change {
+	changes := shootChanges(desired, current)
+	if highlyAvailable(desired) {
+		changes = append(changes, highAvailabilityChange)
+	}
+	if n := desired.Networking; n != nil {
+		changes = compare(changes, "networking.networkId", "", n.NetworkID)
+		changes = compare(changes, "networking.routerId", "", n.RouterID)
+		changes = compare(changes, "networking.workersCIDR", "", n.WorkersCIDR)
+	}
+	for _, w := range desired.Workers {
+		for _, c := range workerChanges(w, Worker{}, false) {
+			c.Field = fmt.Sprintf("workers[%s].%s", w.Name, c.Field)
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Changes of fields updated by UpdateShootCluster. Fields not set in desired spec are ignored.
+func shootChanges(desired, current Spec) []Change {
+	var changes []Change
+	if desired.Purpose != "" {
+		changes = compare(changes, "purpose", current.Purpose, desired.Purpose)
+	}
+	if desired.Kubernetes.Version != "" {
+		changes = compare(changes, "kubernetes.version", current.Kubernetes.Version, desired.Kubernetes.Version)
+	}
+	if desired.Hibernation != nil {
+		changes = compare(changes, "hibernation.schedules", formatSchedules(current.Hibernation), formatSchedules(desired.Hibernation))
+	}
+	if m := desired.Maintenance; m != nil {
+		cm := current.Maintenance
+		if cm == nil {
+			cm = &Maintenance{}
+		}
+		if m.TimeWindow != nil {
+			changes = compare(changes, "maintenance.timeWindow", formatTimeWindow(cm.TimeWindow), formatTimeWindow(m.TimeWindow))
+		}
+		if m.AutoUpdate != nil {
+			var kubernetes, image string
+			if cm.AutoUpdate != nil {
+				kubernetes = fmt.Sprint(cm.AutoUpdate.KubernetesVersion)
+				image = fmt.Sprint(cm.AutoUpdate.MachineImageVersion)
+			}
+			changes = compare(changes, "maintenance.autoUpdate.kubernetesVersion", kubernetes, fmt.Sprint(m.AutoUpdate.KubernetesVersion))
+			changes = compare(changes, "maintenance.autoUpdate.machineImageVersion", image, fmt.Sprint(m.AutoUpdate.MachineImageVersion))
+		}
+	}
+	return changes
+}
+
+// Differences in fields that can not be changed once shoot cluster exists.
+func immutableChanges(desired, current Spec) []string {
+	var warnings []string
+	if ha, live := failureTolerance(desired), failureTolerance(current); ha != "" && live != "" && ha != live {
+		warnings = append(warnings, fmt.Sprintf("controlPlane.highAvailability.failureTolerance is %s and can not be changed to %s", live, ha))
+	}
+	if ha := failureTolerance(desired); ha != "" && !highlyAvailable(current) {
+		warnings = append(warnings, fmt.Sprintf("controlPlane.highAvailability.failureTolerance %s can not be requested, the API chooses it when high availability is enabled", ha))
+	}
+	if desired.ControlPlane != nil && desired.ControlPlane.HighAvailability == nil && highlyAvailable(current) {
+		warnings = append(warnings, "high availability of the control plane can not be disabled once enabled")
+	}
+	if n := desired.Networking; n != nil {
+		c := current.Networking
+		if c == nil {
+			c = &Networking{}
+		}
+		if *n != *c {
+			warnings = append(warnings, "networking can only be set when the shoot cluster is created")
+		}
+	}
+	return warnings
+}
+
+// Changes of worker group fields. Optional fields not set in desired worker group are ignored unless `all` is set.
+func workerChanges(desired, current Worker, all bool) []Change {
+	var changes []Change
+	changes = compare(changes, "machine.type", current.Machine.Type, desired.Machine.Type)
+	changes = compare(changes, "machine.image.name", current.Machine.Image.Name, desired.Machine.Image.Name)
+	changes = compare(changes, "machine.image.version", current.Machine.Image.Version, desired.Machine.Image.Version)
+	changes = compare(changes, "minimum", formatCount(current.Minimum), formatCount(desired.Minimum))
+	changes = compare(changes, "maximum", formatCount(current.Maximum), formatCount(desired.Maximum))
+	if desired.MaxSurge != 0 || all {
+		changes = compare(changes, "maxSurge", formatCount(current.MaxSurge), formatCount(desired.MaxSurge))
+	}
+	if desired.Volume != nil || all {
+		var currentVolume, desiredVolume Volume
+		if current.Volume != nil {
+			currentVolume = *current.Volume
+		}
+		if desired.Volume != nil {
+			desiredVolume = *desired.Volume
+		}
+		changes = compare(changes, "volume.size", currentVolume.Size, desiredVolume.Size)
+		if desiredVolume.Type != "" || all {
+			changes = compare(changes, "volume.type", currentVolume.Type, desiredVolume.Type)
+		}
+	}
+	if len(desired.Zones) > 0 || all {
+		changes = compare(changes, "zones", strings.Join(current.Zones, changeListSeparator), strings.Join(desired.Zones, changeListSeparator))
+	}
+	if desired.Labels != nil || all {
+		changes = compareMaps(changes, "labels", current.Labels, desired.Labels)
+	}
+	if desired.Annotations != nil || all {
+		changes = compareMaps(changes, "annotations", current.Annotations, desired.Annotations)
+	}
+	if desired.Taints != nil || all {
+		changes = compare(changes, "taints", formatTaints(current.Taints), formatTaints(desired.Taints))
+	}
+	return changes
+}
+
+// Current worker group with fields set in desired worker group overlaid. Optional fields are set
+// the same way workerChanges compares them.
+func mergeWorker(desired, current Worker) Worker {
+	merged := current
+	merged.Machine = desired.Machine
+	merged.Minimum = desired.Minimum
+	merged.Maximum = desired.Maximum
+	if desired.MaxSurge != 0 {
+		merged.MaxSurge = desired.MaxSurge
+	}
+	if desired.Volume != nil {
+		volume := *desired.Volume
+		if volume.Type == "" && current.Volume != nil {
+			volume.Type = current.Volume.Type
+		}
+		merged.Volume = &volume
+	}
+	if len(desired.Zones) > 0 {
+		merged.Zones = desired.Zones
+	}
+	if desired.Labels != nil {
+		merged.Labels = desired.Labels
+	}
+	if desired.Annotations != nil {
+		merged.Annotations = desired.Annotations
+	}
+	if desired.Taints != nil {
+		merged.Taints = desired.Taints
+	}
+	return merged
+}
+
+// Change enabling highly available control plane. Failure tolerance is chosen by the API.
+var highAvailabilityChange = Change{Field: "controlPlane.highAvailability", To: "enabled"}
+
+// Reports whether spec has highly available control plane.
+func highlyAvailable(spec Spec) bool {
+	return spec.ControlPlane != nil && spec.ControlPlane.HighAvailability != nil
+}
+
+// Failure tolerance of highly available control plane, empty if not set.
+func failureTolerance(spec Spec) string {
+	if spec.ControlPlane == nil || spec.ControlPlane.HighAvailability == nil {
+		return ""
+	}
+	return spec.ControlPlane.HighAvailability.FailureTolerance
+}
+
+// Append change if values differ.
+func compare(changes []Change, field, from, to string) []Change {
+	if from == to {
+		return changes
+	}
+	return append(changes, Change{Field: field, From: from, To: to})
+}
+
+// Append change of every differing map key.
+func compareMaps(changes []Change, field string, from, to map[string]string) []Change {
+	keys := slices.Sorted(maps.Keys(from))
+	for _, key := range sortedKeys(to) {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		changes = compare(changes, field+"."+key, from[key], to[key])
+	}
+	return changes
+}
+
+func sortedKeys(m map[string]string) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+func formatCount(n int16) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
+
+func formatTimeWindow(tw *TimeWindow) string {
+	if tw == nil {
+		return ""
+	}
+	return tw.Begin + "-" + tw.End
+}
+
+func formatSchedules(h *Hibernation) string {
+	if h == nil {
+		return ""
+	}
+	var schedules []string
+	for _, s := range h.Schedules {
+		schedules = append(schedules, fmt.Sprintf("%s/%s", s.Start, s.End))
+	}
+	return strings.Join(schedules, changeListSeparator)
+}
+
+func formatTaints(taints []Taint) string {
+	var formatted []string
+	for _, t := range taints {
+		formatted = append(formatted, fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect))
+	}
+	return strings.Join(formatted, changeListSeparator)
+}
+
+// Client is the part of cleura.Client used to apply plans.
+type Client interface {
+	cleura.ShootService
+	WaitForShootOperation(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string, opts cleura.WaitOptions) (*cleura.ShootClusterResponse, error)
+}
+
+// GetPlan fetches live shoot cluster and plans changes needed to apply the manifest.
+func GetPlan(ctx context.Context, client Client, desired *Shoot, loc cleura.ShootLocation) (*Plan, error) {
+	live, err := client.GetShootCluster(ctx, loc.GardenDomain, desired.Metadata.Name, loc.Region, loc.ProjectID)
+	if errors.Is(err, cleura.ErrNotFound) {
+		live = nil
+	} else if err != nil {
+		return nil, err
+	}
+	return NewPlan(desired, loc, live)
+}
+
+// ApplyOptions configures Apply.
+type ApplyOptions struct {
+	// Options for waiting for an operation to finish before the next action is started.
+	Wait cleura.WaitOptions
+	// Wait for operation started by the last action as well.
+	WaitForLast bool
+	// Called before each action is started.
+	OnAction func(action Action)
+}
+
+// Apply executes actions of the plan one by one. Shoot clusters accept a single operation at a time,
+// so each action waits for the operation started by the previous one to finish.
+func Apply(ctx context.Context, client Client, plan *Plan, opts ApplyOptions) error {
+	loc := plan.Location
+	name := plan.Shoot.Metadata.Name
+	for i, action := range plan.Actions {
+		if opts.OnAction != nil {
+			opts.OnAction(action)
+		}
+		var err error
+		switch action.Type {
+		case ActionCreate:
+			_, err = client.CreateShootCluster(ctx, loc.GardenDomain, loc.Region, loc.ProjectID, action.shootRequest)
+		case ActionUpdate:
+			_, err = client.UpdateShootCluster(ctx, loc.GardenDomain, loc.Region, loc.ProjectID, name, action.shootRequest)
+		case ActionEnableHA:
+			_, err = client.EnableHaControlPlane(ctx, loc.GardenDomain, loc.Region, loc.ProjectID, name)
+		case ActionAddWorkerGroup:
+			_, err = client.AddWorkerGroup(ctx, loc.GardenDomain, name, loc.Region, loc.ProjectID, action.workerRequest)
+		case ActionUpdateWorkerGroup:
+			_, err = client.UpdateWorkerGroup(ctx, loc.GardenDomain, name, loc.Region, loc.ProjectID, action.WorkerGroup, action.workerRequest)
+		case ActionDeleteWorkerGroup:
+			_, err = client.DeleteWorkerGroup(ctx, loc.GardenDomain, name, loc.Region, loc.ProjectID, action.WorkerGroup)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", plan.Shoot, action, err)
+		}
+		if i == len(plan.Actions)-1 && !opts.WaitForLast {
+			break
+		}
+		wait := opts.Wait
+		wait.RequireHealthy = wait.RequireHealthy && action.Type == ActionCreate
+		if _, err := client.WaitForShootOperation(ctx, loc.GardenDomain, loc.Region, loc.ProjectID, name, wait); err != nil {
+			return fmt.Errorf("%s: %s: %w", plan.Shoot, action, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,480 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura/cleuratest"
+)
+
+var testLocation = cleura.ShootLocation{GardenDomain: "public", Region: "sto2", ProjectID: "demo-project"}
+
+// Live shoot cluster with a single worker group setting every optional field.
+func testLiveShoot() *cleura.ShootClusterResponse {
+	var live cleura.ShootClusterResponse
+	live.Metadata.Name = "demo"
+	live.Spec.Kubernetes.Version = "1.31.4"
+	live.Spec.Provider.Workers = []cleura.WorkerUpdateResponse{{
+		Name:        "sys",
+		Minimum:     1,
+		Maximum:     3,
+		MaxSurge:    2,
+		Machine:     cleura.MachineDetails{Type: "b.2c4gb", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+		Volume:      cleura.VolumeDetails{Size: "50Gi", Type: "standard"},
+		Labels:      map[string]string{"team": "ops"},
+		Annotations: map[string]string{"note": "live"},
+		Taints:      []cleura.Taint{{Key: "dedicated", Value: "ops", Effect: "NoSchedule"}},
+		Zones:       []string{"nova"},
+	}}
+	return &live
+}
+
+func decodeTestShoot(t *testing.T, doc string) *Shoot {
+	t.Helper()
+	shoots, err := Decode(strings.NewReader(doc), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return shoots[0]
+}
+
+const testManifestHeader = `
+apiVersion: cleura.cloud/v1alpha1
+kind: Shoot
+metadata:
+  name: demo
+spec:
+  kubernetes:
+    version: 1.31.4
+  workers:
+    - name: sys
+      machine:
+        type: b.2c4gb
+        image:
+          name: gardenlinux
+          version: 1592.4.0
+      minimum: 1
+`
+
+func TestNewPlanUpdateWorkerGroupKeepsUnmanagedFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		worker  string
+		changes []Change
+		want    cleura.WorkerRequest
+	}{
+		{
+			name:    "only required fields managed",
+			worker:  "      maximum: 5\n",
+			changes: []Change{{Field: "maximum", From: "3", To: "5"}},
+			want: cleura.WorkerRequest{
+				Name:        "sys",
+				Minimum:     1,
+				Maximum:     5,
+				MaxSurge:    2,
+				Machine:     cleura.MachineDetails{Type: "b.2c4gb", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+				Volume:      cleura.VolumeDetails{Size: "50Gi", Type: "standard"},
+				Labels:      []cleura.KeyValuePair{{Key: "team", Value: "ops"}},
+				Annotations: []cleura.KeyValuePair{{Key: "note", Value: "live"}},
+				Taints:      []cleura.Taint{{Key: "dedicated", Value: "ops", Effect: "NoSchedule"}},
+				Zones:       []string{"nova"},
+			},
+		},
+		{
+			name:    "managed labels replace live labels",
+			worker:  "      maximum: 3\n      labels:\n        team: dev\n",
+			changes: []Change{{Field: "labels.team", From: "ops", To: "dev"}},
+			want: cleura.WorkerRequest{
+				Name:        "sys",
+				Minimum:     1,
+				Maximum:     3,
+				MaxSurge:    2,
+				Machine:     cleura.MachineDetails{Type: "b.2c4gb", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+				Volume:      cleura.VolumeDetails{Size: "50Gi", Type: "standard"},
+				Labels:      []cleura.KeyValuePair{{Key: "team", Value: "dev"}},
+				Annotations: []cleura.KeyValuePair{{Key: "note", Value: "live"}},
+				Taints:      []cleura.Taint{{Key: "dedicated", Value: "ops", Effect: "NoSchedule"}},
+				Zones:       []string{"nova"},
+			},
+		},
+		{
+			name:    "managed volume size keeps live volume type",
+			worker:  "      maximum: 3\n      volume:\n        size: 100Gi\n",
+			changes: []Change{{Field: "volume.size", From: "50Gi", To: "100Gi"}},
+			want: cleura.WorkerRequest{
+				Name:        "sys",
+				Minimum:     1,
+				Maximum:     3,
+				MaxSurge:    2,
+				Machine:     cleura.MachineDetails{Type: "b.2c4gb", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+				Volume:      cleura.VolumeDetails{Size: "100Gi", Type: "standard"},
+				Labels:      []cleura.KeyValuePair{{Key: "team", Value: "ops"}},
+				Annotations: []cleura.KeyValuePair{{Key: "note", Value: "live"}},
+				Taints:      []cleura.Taint{{Key: "dedicated", Value: "ops", Effect: "NoSchedule"}},
+				Zones:       []string{"nova"},
+			},
+		},
+		{
+			name:    "managed empty taints remove live taints",
+			worker:  "      maximum: 3\n      taints: []\n",
+			changes: []Change{{Field: "taints", From: "dedicated=ops:NoSchedule"}},
+			want: cleura.WorkerRequest{
+				Name:        "sys",
+				Minimum:     1,
+				Maximum:     3,
+				MaxSurge:    2,
+				Machine:     cleura.MachineDetails{Type: "b.2c4gb", Image: cleura.ImageDetails{Name: "gardenlinux", Version: "1592.4.0"}},
+				Volume:      cleura.VolumeDetails{Size: "50Gi", Type: "standard"},
+				Labels:      []cleura.KeyValuePair{{Key: "team", Value: "ops"}},
+				Annotations: []cleura.KeyValuePair{{Key: "note", Value: "live"}},
+				Taints:      []cleura.Taint{},
+				Zones:       []string{"nova"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := decodeTestShoot(t, testManifestHeader+tt.worker)
+			plan, err := NewPlan(desired, testLocation, testLiveShoot())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan.Actions) != 1 || plan.Actions[0].Type != ActionUpdateWorkerGroup {
+				t.Fatalf("actions = %v, want single %s", plan.Actions, ActionUpdateWorkerGroup)
+			}
+			action := plan.Actions[0]
+			if !reflect.DeepEqual(action.Changes, tt.changes) {
+				t.Errorf("changes = %+v, want %+v", action.Changes, tt.changes)
+			}
+			if got := action.workerRequest.Worker; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("request = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Records method, path and body of requests changing state.
+type recordingTransport struct {
+	next     http.RoundTripper
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	method string
+	path   string
+	body   []byte
+}
+
+func (t *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet {
+		var body []byte
+		if r.Body != nil {
+			var err error
+			if body, err = io.ReadAll(r.Body); err != nil {
+				return nil, err
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		t.requests = append(t.requests, recordedRequest{method: r.Method, path: r.URL.Path, body: body})
+	}
+	return t.next.RoundTrip(r)
+}
+
+func TestApplyUpdateWorkerGroupSendsPlannedRequest(t *testing.T) {
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	srv.API.AddShoot(testLocation.GardenDomain, testLocation.Region, testLocation.ProjectID, *testLiveShoot())
+	recorder := &recordingTransport{next: srv.Client().Transport}
+	client, err := srv.CleuraClient(cleura.WithHTTPClient(&http.Client{Transport: recorder}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	desired := decodeTestShoot(t, testManifestHeader+"      maximum: 5\n      labels:\n        team: dev\n")
+	plan, err := GetPlan(context.Background(), client, desired, testLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(context.Background(), client, plan, ApplyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.requests) != len(plan.Actions) {
+		t.Fatalf("sent %d requests for %d planned actions", len(recorder.requests), len(plan.Actions))
+	}
+	for i, action := range plan.Actions {
+		sent := recorder.requests[i]
+		if method, path := plan.Endpoint(action); sent.method != method || sent.path != path {
+			t.Errorf("%s: sent %s %s, planned %s %s", action, sent.method, sent.path, method, path)
+		}
+		var body cleura.WorkerGroupRequest
+		if err := json.Unmarshal(sent.body, &body); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, action.workerRequest) {
+			t.Errorf("%s: sent body %+v, planned %+v", action, body, action.workerRequest)
+		}
+	}
+	// Applied state matches the plan: only planned changes were made and nothing is left to do
+	live, ok := srv.API.Shoot(testLocation.GardenDomain, testLocation.Region, testLocation.ProjectID, "demo")
+	if !ok {
+		t.Fatal("shoot not found after apply")
+	}
+	before := FromShootCluster(testLiveShoot(), testLocation).Spec.Workers[0]
+	after := FromShootCluster(&live, testLocation).Spec.Workers[0]
+	if changes := workerChanges(after, before, true); !reflect.DeepEqual(changes, plan.Actions[0].Changes) {
+		t.Errorf("applied changes = %+v, planned %+v", changes, plan.Actions[0].Changes)
+	}
+	replan, err := NewPlan(desired, testLocation, &live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replan.Empty() {
+		t.Errorf("plan after apply = %v, want empty", replan.Actions)
+	}
+}
+
+func TestPlanValidateOnlyChangedFields(t *testing.T) {
+	profile := cleuratest.CloudProfileAt(time.Now())
+	// Live shoot cluster created before its Kubernetes and image versions expired
+	live := testLiveShoot()
+	live.Spec.Kubernetes.Version = "1.29.12"
+	live.Spec.Provider.Workers[0].Machine.Image.Version = "1312.3.0"
+	tests := []struct {
+		name   string
+		modify func(s *Shoot)
+		fields []string
+	}{
+		{name: "unchanged", modify: func(s *Shoot) {}},
+		{name: "scaled", modify: func(s *Shoot) { s.Spec.Workers[0].Maximum = 5 }},
+		{name: "labels changed", modify: func(s *Shoot) { s.Spec.Workers[0].Labels = map[string]string{"team": "dev"} }},
+		{name: "upgraded", modify: func(s *Shoot) {
+			s.Spec.Kubernetes.Version = "1.30.8"
+			s.Spec.Workers[0].Machine.Image.Version = "1592.4.0"
+		}},
+		{
+			name:   "changed to unknown image version",
+			modify: func(s *Shoot) { s.Spec.Workers[0].Machine.Image.Version = "1592.9.0" },
+			fields: []string{"workers[sys].machine.image.version"},
+		},
+		{
+			name:   "changed to unusable machine type",
+			modify: func(s *Shoot) { s.Spec.Workers[0].Machine.Type = "b.1c2gb" },
+			fields: []string{"workers[sys].machine.type"},
+		},
+		{
+			name:   "changed to unknown kubernetes version",
+			modify: func(s *Shoot) { s.Spec.Kubernetes.Version = "1.30.1" },
+			fields: []string{"shoot.kubernetesVersion.version"},
+		},
+		{
+			name: "added worker group with expired image",
+			modify: func(s *Shoot) {
+				w := s.Spec.Workers[0]
+				w.Name = "extra"
+				s.Spec.Workers = append(s.Spec.Workers, w)
+			},
+			fields: []string{"workers[extra].machine.image.version"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := Export(live, testLocation)
+			tt.modify(desired)
+			plan, err := NewPlan(desired, testLocation, live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = plan.Validate(&profile)
+			var ve *cleura.ValidationError
+			if err != nil && !errors.As(err, &ve) {
+				t.Fatal(err)
+			}
+			var fields []string
+			if ve != nil {
+				for _, problem := range ve.Problems {
+					fields = append(fields, problem.Field)
+				}
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("problems in %v, want %v (error: %v)", fields, tt.fields, err)
+			}
+		})
+	}
+}
+
+func TestPlanValidateCreate(t *testing.T) {
+	profile := cleuratest.CloudProfileAt(time.Now())
+	desired := decodeTestShoot(t, strings.Replace(testManifestHeader, "1592.4.0", "1312.3.0", 1)+"      maximum: 3\n")
+	plan, err := NewPlan(desired, testLocation, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ve *cleura.ValidationError
+	if err := plan.Validate(&profile); !errors.As(err, &ve) || len(ve.Problems) != 1 || ve.Problems[0].Field != "shoot.provider.workers[0].machine.image.version" {
+		t.Errorf("error = %v, want expired image version of the new shoot cluster", err)
+	}
+}
+
+func TestApplyEnableHA(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlPlane string
+		warnings     []string
+		replan       []string
+	}{
+		{name: "failure tolerance left to the API", controlPlane: "  controlPlane:\n    highAvailability: {}\n"},
+		{
+			name:         "failure tolerance chosen by the API",
+			controlPlane: "  controlPlane:\n    highAvailability:\n      failureTolerance: node\n",
+			warnings:     []string{"controlPlane.highAvailability.failureTolerance node can not be requested, the API chooses it when high availability is enabled"},
+		},
+		{
+			name:         "failure tolerance differing from the API choice",
+			controlPlane: "  controlPlane:\n    highAvailability:\n      failureTolerance: zone\n",
+			warnings:     []string{"controlPlane.highAvailability.failureTolerance zone can not be requested, the API chooses it when high availability is enabled"},
+			replan:       []string{"controlPlane.highAvailability.failureTolerance is node and can not be changed to zone"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := cleuratest.NewAPI()
+			api.SetOperationDuration(0)
+			srv := cleuratest.NewServer(api)
+			defer srv.Close()
+			api.AddShoot(testLocation.GardenDomain, testLocation.Region, testLocation.ProjectID, *testLiveShoot())
+			recorder := &recordingTransport{next: srv.Client().Transport}
+			client, err := srv.CleuraClient(cleura.WithHTTPClient(&http.Client{Transport: recorder}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			desired := Export(testLiveShoot(), testLocation)
+			controlPlane := decodeTestShoot(t, testManifestHeader+"      maximum: 3\n"+tt.controlPlane)
+			desired.Spec.ControlPlane = controlPlane.Spec.ControlPlane
+			plan, err := GetPlan(context.Background(), client, desired, testLocation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan.Actions) != 1 || plan.Actions[0].Type != ActionEnableHA {
+				t.Fatalf("actions = %v, want single %s", plan.Actions, ActionEnableHA)
+			}
+			if !reflect.DeepEqual(plan.Warnings, tt.warnings) {
+				t.Errorf("warnings = %q, want %q", plan.Warnings, tt.warnings)
+			}
+			if err := Apply(context.Background(), client, plan, ApplyOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if len(recorder.requests) != 1 || len(recorder.requests[0].body) != 0 {
+				t.Errorf("requests = %+v, want single request without body", recorder.requests)
+			}
+			replan, err := GetPlan(context.Background(), client, desired, testLocation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !replan.Empty() || !reflect.DeepEqual(replan.Warnings, tt.replan) {
+				t.Errorf("plan after apply = %v, warnings %q, want no actions and warnings %q", replan.Actions, replan.Warnings, tt.replan)
+			}
+		})
+	}
+}
+
+// Kubernetes version left out of the manifest is not managed: live version is kept and not sent.
+func TestPlanKubernetesVersionOmitted(t *testing.T) {
+	header := strings.Replace(testManifestHeader, "  kubernetes:\n    version: 1.31.4\n", "", 1)
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	srv.API.AddShoot(testLocation.GardenDomain, testLocation.Region, testLocation.ProjectID, *testLiveShoot())
+	recorder := &recordingTransport{next: srv.Client().Transport}
+	client, err := srv.CleuraClient(cleura.WithHTTPClient(&http.Client{Transport: recorder}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	unchanged := decodeTestShoot(t, header+"      maximum: 3\n")
+	plan, err := GetPlan(ctx, client, unchanged, testLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan = %v, want empty", plan.Actions)
+	}
+	desired := decodeTestShoot(t, header+"      maximum: 3\n  purpose: production\n")
+	plan, err = GetPlan(ctx, client, desired, testLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Change{{Field: "purpose", From: "", To: "production"}}
+	if len(plan.Actions) != 1 || plan.Actions[0].Type != ActionUpdate || !reflect.DeepEqual(plan.Actions[0].Changes, want) {
+		t.Fatalf("plan = %+v, want update of purpose only", plan.Actions)
+	}
+	if err := Apply(ctx, client, plan, ApplyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.requests) != 1 {
+		t.Fatalf("sent %d requests, want 1", len(recorder.requests))
+	}
+	var body struct {
+		Shoot map[string]json.RawMessage `json:"shoot"`
+	}
+	if err := json.Unmarshal(recorder.requests[0].body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := body.Shoot["kubernetes"]; ok {
+		t.Errorf("sent body %s, want no kubernetes version", recorder.requests[0].body)
+	}
+	live, _ := srv.API.Shoot(testLocation.GardenDomain, testLocation.Region, testLocation.ProjectID, "demo")
+	if live.Spec.Kubernetes.Version != "1.31.4" {
+		t.Errorf("kubernetes version after apply = %s, want 1.31.4", live.Spec.Kubernetes.Version)
+	}
+	// Version is required to create a shoot cluster
+	if _, err := NewPlan(unchanged, testLocation, nil); !errors.Is(err, cleura.ErrInvalidRequest) {
+		t.Errorf("error planning creation = %v, want invalid request", err)
+	}
+}
+
+func TestApplyRemoveHibernationSchedules(t *testing.T) {
+	live := testLiveShoot()
+	live.Spec.Hibernation.HibernationResponseSchedules = []cleura.HibernationResponseSchedule{{Start: "00 18 * * 1,2,3,4,5", End: "00 08 * * 1,2,3,4,5", Location: "Etc/UTC"}}
+	srv := cleuratest.NewServer(nil)
+	defer srv.Close()
+	srv.API.AddShoot(testLocation.GardenDomain, testLocation.Region, testLocation.ProjectID, *live)
+	recorder := &recordingTransport{next: srv.Client().Transport}
+	client, err := srv.CleuraClient(cleura.WithHTTPClient(&http.Client{Transport: recorder}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	desired := decodeTestShoot(t, testManifestHeader+"      maximum: 3\n  hibernation:\n    schedules: []\n")
+	plan, err := GetPlan(ctx, client, desired, testLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(ctx, client, plan, ApplyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.requests) != 1 {
+		t.Fatalf("sent %d requests, want 1", len(recorder.requests))
+	}
+	var body struct {
+		Shoot struct {
+			Hibernation map[string]json.RawMessage `json:"hibernation"`
+		} `json:"shoot"`
+	}
+	if err := json.Unmarshal(recorder.requests[0].body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if schedules := string(body.Shoot.Hibernation["schedules"]); schedules != "[]" {
+		t.Errorf("sent body %s, want empty hibernation schedules", recorder.requests[0].body)
+	}
+	updated, _ := srv.API.Shoot(testLocation.GardenDomain, testLocation.Region, testLocation.ProjectID, "demo")
+	if schedules := updated.Spec.Hibernation.HibernationResponseSchedules; len(schedules) != 0 {
+		t.Errorf("hibernation schedules after apply = %+v, want none", schedules)
+	}
+}